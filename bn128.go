@@ -14,9 +14,12 @@ package gobn128
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
+	"math/bits"
 )
 
 var (
@@ -26,8 +29,35 @@ var (
 	ErrInvalidPairing = errors.New("bn128: pairing check failed")
 	// ErrInvalidEncoding indicates invalid serialization format
 	ErrInvalidEncoding = errors.New("bn128: invalid encoding")
+	// ErrNonCanonical indicates an encoded field element is not below its
+	// modulus, p for coordinates and Order for scalars
+	ErrNonCanonical = errors.New("bn128: non-canonical field element")
+	// ErrLengthMismatch indicates paired input slices have different lengths
+	ErrLengthMismatch = errors.New("bn128: input lengths differ")
+	// ErrNotInSubgroup indicates a point is on the curve but outside the
+	// prime-order subgroup
+	ErrNotInSubgroup = errors.New("bn128: point not in prime-order subgroup")
 )
 
+// PairingInputError reports which element of a pairing check input was
+// rejected. Err is ErrInvalidPoint or ErrNotInSubgroup, so callers can use
+// errors.Is on it as well as errors.As to recover the index.
+type PairingInputError struct {
+	// Index is the position of the offending pair
+	Index int
+	// Group is "G1" or "G2"
+	Group string
+	Err   error
+}
+
+func (e *PairingInputError) Error() string {
+	return fmt.Sprintf("%v (%s element of pair %d)", e.Err, e.Group, e.Index)
+}
+
+func (e *PairingInputError) Unwrap() error {
+	return e.Err
+}
+
 // Curve parameters
 var (
 	// P is the base field modulus
@@ -43,34 +73,138 @@ var (
 	GeneratorG1Y = big.NewInt(2)
 
 	// GeneratorG2X and GeneratorG2Y are the G2 generator coordinates (over Fp2)
-	GeneratorG2X = &Fp2{
+	GeneratorG2X = NewFp2(
 		fromHex("1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed"),
 		fromHex("198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2"),
-	}
-	GeneratorG2Y = &Fp2{
+	)
+	GeneratorG2Y = NewFp2(
 		fromHex("12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa"),
 		fromHex("090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b"),
-	}
+	)
 
 	// TwistB is the curve coefficient for G2: y² = x³ + b where b = 3/(9+u)
-	TwistB = &Fp2{
+	TwistB = NewFp2(
 		fromHex("2b149d40ceb8aaae81be18991be06ac3b5b4c5e559dbefa33267e6dc24a138e5"),
 		fromHex("009713b03af0fed4cd2cafadeed8fdf4a74fa084e52d1852e4a2bd0685c315d2"),
+	)
+
+	// curveB is the G1 curve coefficient b = 3
+	curveB = NewFp(big.NewInt(3))
+
+	// frobeniusCoeffs[i-1][k-1] is γ(i, k) = ξ^(k(p^i - 1)/6) with ξ = 9+u,
+	// the factor picked up by the basis element w^k of Fp12 under the
+	// p^i-power Frobenius. The entries for i = 2 lie in Fp.
+	frobeniusCoeffs = [3][5]Fp2{
+		// p¹
+		{
+			*NewFp2(
+				fromHex("1284b71c2865a7dfe8b99fdd76e68b605c521e08292f2176d60b35dadcc9e470"),
+				fromHex("246996f3b4fae7e6a6327cfe12150b8e747992778eeec7e5ca5cf05f80f362ac"),
+			),
+			*NewFp2(
+				fromHex("2fb347984f7911f74c0bec3cf559b143b78cc310c2c3330c99e39557176f553d"),
+				fromHex("16c9e55061ebae204ba4cc8bd75a079432ae2a1d0b7c9dce1665d51c640fcba2"),
+			),
+			*NewFp2(
+				fromHex("063cf305489af5dcdc5ec698b6e2f9b9dbaae0eda9c95998dc54014671a0135a"),
+				fromHex("07c03cbcac41049a0704b5a7ec796f2b21807dc98fa25bd282d37f632623b0e3"),
+			),
+			*NewFp2(
+				fromHex("05b54f5e64eea80180f3c0b75a181e84d33365f7be94ec72848a1f55921ea762"),
+				fromHex("2c145edbe7fd8aee9f3a80b03b0b1c923685d2ea1bdec763c13b4711cd2b8126"),
+			),
+			*NewFp2(
+				fromHex("0183c1e74f798649e93a3661a4353ff4425c459b55aa1bd32ea2c810eab7692f"),
+				fromHex("12acf2ca76fd0675a27fb246c7729f7db080cb99678e2ac024c6b8ee6e0c2c4b"),
+			),
+		},
+		// p²
+		{
+			*NewFp2(
+				fromHex("30644e72e131a0295e6dd9e7e0acccb0c28f069fbb966e3de4bd44e5607cfd49"),
+				new(big.Int),
+			),
+			*NewFp2(
+				fromHex("30644e72e131a0295e6dd9e7e0acccb0c28f069fbb966e3de4bd44e5607cfd48"),
+				new(big.Int),
+			),
+			*NewFp2(
+				fromHex("30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd46"),
+				new(big.Int),
+			),
+			*NewFp2(
+				fromHex("000000000000000059e26bcea0d48bacd4f263f1acdb5c4f5763473177fffffe"),
+				new(big.Int),
+			),
+			*NewFp2(
+				fromHex("000000000000000059e26bcea0d48bacd4f263f1acdb5c4f5763473177ffffff"),
+				new(big.Int),
+			),
+		},
+		// p³
+		{
+			*NewFp2(
+				fromHex("19dc81cfcc82e4bbefe9608cd0acaa90894cb38dbe55d24ae86f7d391ed4a67f"),
+				fromHex("00abf8b60be77d7306cbeee33576139d7f03a5e397d439ec7694aa2bf4c0c101"),
+			),
+			*NewFp2(
+				fromHex("0856e078b755ef0abaff1c77959f25ac805ffd3d5d6942d37b746ee87bdcfb6d"),
+				fromHex("04f1de41b3d1766fa9f30e6dec26094f0fdf31bf98ff2631380cab2baaa586de"),
+			),
+			*NewFp2(
+				fromHex("2a275b6d9896aa4cdbf17f1dca9e5ea3bbd689a3bea870f45fcc8ad066dce9ed"),
+				fromHex("28a411b634f09b8fb14b900e9507e9327600ecc7d8cf6ebab94d0cb3b2594c64"),
+			),
+			*NewFp2(
+				fromHex("0bc58c6611c08dab19bee0f7b5b2444ee633094575b06bcb0e1a92bc3ccbf066"),
+				fromHex("23d5e999e1910a12feb0f6ef0cd21d04a44a9e08737f96e55fe3ed9d730c239f"),
+			),
+			*NewFp2(
+				fromHex("13c49044952c0905711699fa3b4d3f692ed68098967c84a5ebde847076261b43"),
+				fromHex("16db366a59b1dd0b9fb1b2282a48633d3e2ddaea200280211f25041384282499"),
+			),
+		},
 	}
 
-	// xiToPMinus1Over6 is used in the final exponentiation
-	xiToPMinus1Over6 = &Fp2{
-		fromHex("16c9e55061ebae204ba4cc8bd75a079432ae2a1d0b7c9dce1665d51c640fcba2"),
-		fromHex("063cf305489af5dcdc5ec698b6e2f9b9dbaae0eda9c95998dc54014671a0135a"),
-	}
+	// xiToPMinus1Over3 and xiToPMinus1Over2 are γ(1, 2) and γ(1, 3), the
+	// constants of the ψ endomorphism on the twist
+	xiToPMinus1Over3 = &frobeniusCoeffs[0][1]
+	xiToPMinus1Over2 = &frobeniusCoeffs[0][2]
 
-	// xiToPMinus1Over3 is used in the final exponentiation
-	xiToPMinus1Over3 = &Fp2{
-		fromHex("06c990cc9b6bf4c3c6040c2e85e8c0c0c9c99c6d3c1b4c6f4c5c5c5c5c5c5c5c"),
-		fromHex("1787d6f5e7f0c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7c7"),
-	}
+	// xiToPSquaredMinus1Over3 is γ(2, 2), the x-coordinate factor of π² on
+	// the twist
+	xiToPSquaredMinus1Over3 = &frobeniusCoeffs[1][1].a
+
+	// sixUPlus2 is the optimal ate loop parameter 6u+2 where u = 4965661367192848881
+	sixUPlus2 = fromHex("19d797039be763ba8")
+
+	// sixUPlus2NAF is the non-adjacent form of 6u+2, least significant
+	// digit first. It has 22 non-zero digits against 37 set bits in binary,
+	// so the Miller loop does 15 fewer line additions for one extra doubling.
+	sixUPlus2NAF = naf(sixUPlus2)
+
+	// curveU is the BN parameter u = 4965661367192848881
+	curveU = fromHex("44e992b44a6909f1")
 )
 
+// naf returns the non-adjacent form of a positive x, least significant
+// digit first: digits in {-1, 0, 1} with no two adjacent digits non-zero
+func naf(x *big.Int) []int8 {
+	k := new(big.Int).Set(x)
+	var digits []int8
+	for k.Sign() > 0 {
+		var d int8
+		if k.Bit(0) == 1 {
+			// d = 2 - (k mod 4), so that k - d is divisible by 4
+			d = 2 - int8(k.Bits()[0]&3)
+			k.Sub(k, big.NewInt(int64(d)))
+		}
+		digits = append(digits, d)
+		k.Rsh(k, 1)
+	}
+	return digits
+}
+
 // Helper function to convert hex string to big.Int
 func fromHex(s string) *big.Int {
 	n, ok := new(big.Int).SetString(s, 16)
@@ -81,80 +215,470 @@ func fromHex(s string) *big.Int {
 }
 
 // ============================================================================
-// Fp - Base Field Element
+// Montgomery limb arithmetic shared by Fp and Fr
 // ============================================================================
-// Fp represents an element from a big.Int
-type Fp struct {
-	n *big.Int
+
+// montField holds the constants of a prime field whose elements are kept in
+// Montgomery form as four little-endian 64-bit limbs. Both moduli are below
+// 2^254, so a sum of two reduced elements cannot overflow 256 bits and the
+// final CIOS carry word can be dropped.
+type montField struct {
+	modulus [4]uint64 // the prime m
+	one     [4]uint64 // R mod m, where R = 2^256
+	r2      [4]uint64 // R² mod m, used to convert into Montgomery form
+	mMinus2 [4]uint64 // m-2, the inversion exponent by Fermat's little theorem
+	invNeg  uint64    // -m⁻¹ mod 2^64
+}
+
+// montSetCanonical sets z from a 32-byte big-endian value, reporting false
+// and leaving z unspecified if the value is not below f's modulus
+func montSetCanonical(z *[4]uint64, buf []byte, f *montField) bool {
+	z[3] = binary.BigEndian.Uint64(buf[0:8])
+	z[2] = binary.BigEndian.Uint64(buf[8:16])
+	z[1] = binary.BigEndian.Uint64(buf[16:24])
+	z[0] = binary.BigEndian.Uint64(buf[24:32])
+
+	var b uint64
+	_, b = bits.Sub64(z[0], f.modulus[0], 0)
+	_, b = bits.Sub64(z[1], f.modulus[1], b)
+	_, b = bits.Sub64(z[2], f.modulus[2], b)
+	_, b = bits.Sub64(z[3], f.modulus[3], b)
+	montMul(z, z, &f.r2, f)
+	return b == 1
+}
+
+// montPutBytes writes the 32-byte big-endian canonical encoding of x into buf
+func montPutBytes(buf []byte, x *[4]uint64, f *montField) {
+	var t [4]uint64
+	montMul(&t, x, &[4]uint64{1}, f)
+	binary.BigEndian.PutUint64(buf[0:8], t[3])
+	binary.BigEndian.PutUint64(buf[8:16], t[2])
+	binary.BigEndian.PutUint64(buf[16:24], t[1])
+	binary.BigEndian.PutUint64(buf[24:32], t[0])
+}
+
+// montReduce subtracts the modulus from z if z is not below it, without
+// branching on z
+func montReduce(z *[4]uint64, f *montField) {
+	var t [4]uint64
+	var b uint64
+	t[0], b = bits.Sub64(z[0], f.modulus[0], 0)
+	t[1], b = bits.Sub64(z[1], f.modulus[1], b)
+	t[2], b = bits.Sub64(z[2], f.modulus[2], b)
+	t[3], b = bits.Sub64(z[3], f.modulus[3], b)
+
+	// mask is all ones if there was no borrow, i.e. z >= m
+	mask := b - 1
+	z[0] = t[0]&mask | z[0]&^mask
+	z[1] = t[1]&mask | z[1]&^mask
+	z[2] = t[2]&mask | z[2]&^mask
+	z[3] = t[3]&mask | z[3]&^mask
+}
+
+// montAdd sets z = x + y
+func montAdd(z, x, y *[4]uint64, f *montField) {
+	var c uint64
+	z[0], c = bits.Add64(x[0], y[0], 0)
+	z[1], c = bits.Add64(x[1], y[1], c)
+	z[2], c = bits.Add64(x[2], y[2], c)
+	z[3], _ = bits.Add64(x[3], y[3], c)
+	montReduce(z, f)
+}
+
+// montSub sets z = x - y
+func montSub(z, x, y *[4]uint64, f *montField) {
+	var b uint64
+	z[0], b = bits.Sub64(x[0], y[0], 0)
+	z[1], b = bits.Sub64(x[1], y[1], b)
+	z[2], b = bits.Sub64(x[2], y[2], b)
+	z[3], b = bits.Sub64(x[3], y[3], b)
+
+	// Add m back on borrow, masked rather than branched on
+	mask := -b
+	var c uint64
+	z[0], c = bits.Add64(z[0], f.modulus[0]&mask, 0)
+	z[1], c = bits.Add64(z[1], f.modulus[1]&mask, c)
+	z[2], c = bits.Add64(z[2], f.modulus[2]&mask, c)
+	z[3], _ = bits.Add64(z[3], f.modulus[3]&mask, c)
+}
+
+// montNeg sets z = -x
+func montNeg(z, x *[4]uint64, f *montField) {
+	// mask is all ones unless x == 0, whose negation is 0 rather than m
+	nz := x[0] | x[1] | x[2] | x[3]
+	mask := -((nz | -nz) >> 63)
+
+	var b uint64
+	z[0], b = bits.Sub64(f.modulus[0], x[0], 0)
+	z[1], b = bits.Sub64(f.modulus[1], x[1], b)
+	z[2], b = bits.Sub64(f.modulus[2], x[2], b)
+	z[3], _ = bits.Sub64(f.modulus[3], x[3], b)
+	z[0] &= mask
+	z[1] &= mask
+	z[2] &= mask
+	z[3] &= mask
+}
+
+// montMulGeneric is the portable CIOS Montgomery multiplication z = x*y/R
+func montMulGeneric(z, x, y *[4]uint64, f *montField) {
+	var t [4]uint64
+	var c0, c1, c2 uint64
+	for i := 0; i < 4; i++ {
+		v := x[i]
+		c1, c0 = madd1(v, y[0], t[0])
+		m := c0 * f.invNeg
+		c2 = madd0(m, f.modulus[0], c0)
+		c1, c0 = madd2(v, y[1], c1, t[1])
+		c2, t[0] = madd2(m, f.modulus[1], c2, c0)
+		c1, c0 = madd2(v, y[2], c1, t[2])
+		c2, t[1] = madd2(m, f.modulus[2], c2, c0)
+		c1, c0 = madd2(v, y[3], c1, t[3])
+		t[3], t[2] = madd3(m, f.modulus[3], c0, c2, c1)
+	}
+	*z = t
+	montReduce(z, f)
+}
+
+// madd0 returns the high word of a*b + c
+func madd0(a, b, c uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, carry := bits.Add64(lo, c, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	return hi
+}
+
+// madd1 returns a*b + c as (hi, lo)
+func madd1(a, b, c uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(a, b)
+	lo, carry := bits.Add64(lo, c, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	return hi, lo
+}
+
+// madd2 returns a*b + c + d as (hi, lo)
+func madd2(a, b, c, d uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(a, b)
+	c, carry := bits.Add64(c, d, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	lo, carry = bits.Add64(lo, c, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	return hi, lo
+}
+
+// madd3 returns a*b + c + d + e<<64 as (hi, lo)
+func madd3(a, b, c, d, e uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(a, b)
+	c, carry := bits.Add64(c, d, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	lo, carry = bits.Add64(lo, c, 0)
+	hi, _ = bits.Add64(hi, e, carry)
+	return hi, lo
+}
+
+// montExp sets z = x^e where e is given as little-endian 64-bit limbs
+func montExp(z, x *[4]uint64, e []uint64, f *montField) {
+	base := *x
+	result := f.one
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			montMul(&result, &result, &result, f)
+			if (e[i]>>uint(j))&1 == 1 {
+				montMul(&result, &result, &base, f)
+			}
+		}
+	}
+	*z = result
+}
+
+// montInverse sets z = x⁻¹ (or 0 if x == 0). By Fermat's little theorem
+// x^(m-1) ≡ 1, so x⁻¹ ≡ x^(m-2).
+func montInverse(z, x *[4]uint64, f *montField) {
+	montExp(z, x, f.mMinus2[:], f)
+}
+
+// montBatchInverse sets z[i] = x[i]⁻¹ (or 0 if x[i] == 0) for every i, using
+// one inversion in total. z and x may be the same slice.
+func montBatchInverse[E ~[4]uint64](z, x []E, f *montField) {
+	// prefix[i] is the product of the non-zero x[j] with j < i
+	prefix := make([][4]uint64, len(x))
+	acc := f.one
+	for i := range x {
+		prefix[i] = acc
+		if v := [4]uint64(x[i]); v != ([4]uint64{}) {
+			montMul(&acc, &acc, &v, f)
+		}
+	}
+
+	// acc holds the inverse of the product of x[0..i] on each step down
+	montInverse(&acc, &acc, f)
+	for i := len(x) - 1; i >= 0; i-- {
+		v := [4]uint64(x[i])
+		if v == ([4]uint64{}) {
+			z[i] = E{}
+			continue
+		}
+		var inv [4]uint64
+		montMul(&inv, &acc, &prefix[i], f)
+		montMul(&acc, &acc, &v, f)
+		z[i] = E(inv)
+	}
 }
 
-// NewFp creates a neew field element from big.Int
+// ============================================================================
+// Fp - Base Field Element
+// ============================================================================
+
+// Fp represents an element of the base field in Montgomery form, stored as
+// four little-endian 64-bit limbs. The zero value is the field element 0.
+type Fp [4]uint64
+
+// fpModulus is P as little-endian 64-bit limbs
+var fpModulus = Fp{0x3c208c16d87cfd47, 0x97816a916871ca8d, 0xb85045b68181585d, 0x30644e72e131a029}
+
+// fpOne is 1 in Montgomery form, i.e. R mod p where R = 2^256
+var fpOne = Fp{0xd35d438dc58f0d9d, 0x0a78eb28f5c70b3d, 0x666ea36f7879462c, 0x0e0a77c19a07df2f}
+
+// fpR2 is R² mod p, used to convert into Montgomery form
+var fpR2 = Fp{0xf32cfc5b538afa89, 0xb5e71911d44501fb, 0x47ab1eff0a417ff6, 0x06d89f71cab8351f}
+
+// fpPMinus2 is p-2, the exponent used for inversion by Fermat's little theorem
+var fpPMinus2 = [4]uint64{0x3c208c16d87cfd45, 0x97816a916871ca8d, 0xb85045b68181585d, 0x30644e72e131a029}
+
+// fpPPlus1Over4 is (p+1)/4, the square root exponent for p ≡ 3 mod 4
+var fpPPlus1Over4 = [4]uint64{0x4f082305b61f3f52, 0x65e05aa45a1c72a3, 0x6e14116da0605617, 0x0c19139cb84c680a}
+
+// fpPMinus3Over4 is (p-3)/4, used by the Fp2 square root
+var fpPMinus3Over4 = [4]uint64{0x4f082305b61f3f51, 0x65e05aa45a1c72a3, 0x6e14116da0605617, 0x0c19139cb84c680a}
+
+// fpPMinus1Over2 is (p-1)/2, the Euler criterion exponent
+var fpPMinus1Over2 = [4]uint64{0x9e10460b6c3e7ea3, 0xcbc0b548b438e546, 0xdc2822db40c0ac2e, 0x183227397098d014}
+
+// fpInvNeg is -p⁻¹ mod 2^64
+const fpInvNeg = 0x87d20782e4866389
+
+// fpField is Fp as a montField, for the limb arithmetic shared with Fr
+var fpField = montField{fpModulus, fpOne, fpR2, fpPMinus2, fpInvNeg}
+
+// NewFp creates a new field element from big.Int
 func NewFp(n *big.Int) *Fp {
-	return &Fp{n: new(big.Int).Mod(n, P)}
+	z := new(Fp)
+	fpSetBig(z, n)
+	return z
 }
 
 // Copy creates a deep copy of the field element
 func (f *Fp) Copy() *Fp {
-	return &Fp{n: new(big.Int).Set(f.n)}
+	z := *f
+	return &z
 }
 
 // Add computes f + g in Fp
 func (f *Fp) Add(g *Fp) *Fp {
-	result := new(big.Int).Add(f.n, g.n)
-	return &Fp{n: result.Mod(result, P)}
+	z := new(Fp)
+	fpAdd(z, f, g)
+	return z
 }
 
 // Sub computes f - g in Fp
 func (f *Fp) Sub(g *Fp) *Fp {
-	result := new(big.Int).Sub(f.n, g.n)
-	return &Fp{n: result.Mod(result, P)}
+	z := new(Fp)
+	fpSub(z, f, g)
+	return z
 }
 
 // Mul computes f * g in Fp
 func (f *Fp) Mul(g *Fp) *Fp {
-	result := new(big.Int).Mul(f.n, g.n)
-	return &Fp{n: result.Mod(result, P)}
+	z := new(Fp)
+	fpMul(z, f, g)
+	return z
 }
 
 // Square computes f² in Fp
 func (f *Fp) Square() *Fp {
-	result := new(big.Int).Mul(f.n, f.n)
-	return &Fp{n: result.Mod(result, P)}
+	z := new(Fp)
+	fpSquare(z, f)
+	return z
 }
 
 // Inverse computes f⁻¹ in Fp using Fermat's little theorem
 func (f *Fp) Inverse() *Fp {
-	if f.IsZero() {
-		return &Fp{n: big.NewInt(0)}
+	z := new(Fp)
+	fpInverse(z, f)
+	return z
+}
+
+// BatchInverseFp returns the inverses of xs with a single field inversion
+// and three multiplications per element (Montgomery's trick). Zero elements
+// are skipped and map to zero, as in Inverse.
+func BatchInverseFp(xs []*Fp) []*Fp {
+	vals := make([]Fp, len(xs))
+	for i, x := range xs {
+		vals[i] = *x
+	}
+	fpBatchInverse(vals, vals)
+	out := make([]*Fp, len(xs))
+	for i := range vals {
+		out[i] = &vals[i]
 	}
-	// By Fermat's little theorem: a^(p-1) ≡ 1 (mod p)
-	// Therefore: a^(-1) ≡ a^(p-2) (mod p)
-	pMinus2 := new(big.Int).Sub(P, big.NewInt(2))
-	result := new(big.Int).Exp(f.n, pMinus2, P)
-	return &Fp{n: result}
+	return out
 }
 
 // Neg computes -f in Fp
 func (f *Fp) Neg() *Fp {
-	if f.IsZero() {
-		return &Fp{n: big.NewInt(0)}
-	}
-	return &Fp{n: new(big.Int).Sub(P, f.n)}
+	z := new(Fp)
+	fpNeg(z, f)
+	return z
 }
 
 // IsZero returns true if f == 0
 func (f *Fp) IsZero() bool {
-	return f.n.Sign() == 0
+	return f[0]|f[1]|f[2]|f[3] == 0
 }
 
 // Equal returns true if f == g
 func (f *Fp) Equal(g *Fp) bool {
-	return f.n.Cmp(g.n) == 0
+	return *f == *g
+}
+
+// Sqrt returns a square root of f and whether f is a square. Since
+// p ≡ 3 mod 4 the root is f^((p+1)/4); the other root is its negation.
+func (f *Fp) Sqrt() (*Fp, bool) {
+	z := new(Fp)
+	if !fpSqrt(z, f) {
+		return nil, false
+	}
+	return z, true
+}
+
+// Legendre returns the Legendre symbol of f: 1 if f is a non-zero square,
+// -1 if it is not a square and 0 if f == 0
+func (f *Fp) Legendre() int {
+	var t Fp
+	fpExp(&t, f, fpPMinus1Over2[:])
+	switch {
+	case t.IsZero():
+		return 0
+	case t == fpOne:
+		return 1
+	default:
+		return -1
+	}
 }
 
 // BigInt returns the big.Int representation
 func (f *Fp) BigInt() *big.Int {
-	return new(big.Int).Set(f.n)
+	var buf [32]byte
+	fpPutBytes(buf[:], f)
+	return new(big.Int).SetBytes(buf[:])
+}
+
+// fpSetBig sets z to n mod p in Montgomery form
+func fpSetBig(z *Fp, n *big.Int) {
+	v := n
+	if n.Sign() < 0 || n.Cmp(P) >= 0 {
+		v = new(big.Int).Mod(n, P)
+	}
+	var buf [32]byte
+	v.FillBytes(buf[:])
+	fpSetBytes(z, buf[:])
+}
+
+// fpSetBytes sets z from a 32-byte big-endian value that must be below p
+func fpSetBytes(z *Fp, buf []byte) {
+	montSetCanonical((*[4]uint64)(z), buf, &fpField)
+}
+
+// fpSetCanonical sets z from a 32-byte big-endian value, reporting false
+// and leaving z unspecified if the value is not below p
+func fpSetCanonical(z *Fp, buf []byte) bool {
+	return montSetCanonical((*[4]uint64)(z), buf, &fpField)
+}
+
+// fpPutBytes writes the 32-byte big-endian canonical encoding of x into buf
+func fpPutBytes(buf []byte, x *Fp) {
+	montPutBytes(buf, (*[4]uint64)(x), &fpField)
+}
+
+// fpFromMont converts x out of Montgomery form
+func fpFromMont(z, x *Fp) {
+	fpMul(z, x, &Fp{1})
+}
+
+// fpAdd sets z = x + y
+func fpAdd(z, x, y *Fp) {
+	montAdd((*[4]uint64)(z), (*[4]uint64)(x), (*[4]uint64)(y), &fpField)
+}
+
+// fpDouble sets z = 2x
+func fpDouble(z, x *Fp) {
+	fpAdd(z, x, x)
+}
+
+// fpHalve sets z = x/2. Halving commutes with the Montgomery factor, so an
+// odd x is made even by adding p before shifting.
+func fpHalve(z, x *Fp) {
+	var c uint64
+	*z = *x
+	if z[0]&1 == 1 {
+		z[0], c = bits.Add64(z[0], fpModulus[0], 0)
+		z[1], c = bits.Add64(z[1], fpModulus[1], c)
+		z[2], c = bits.Add64(z[2], fpModulus[2], c)
+		z[3], c = bits.Add64(z[3], fpModulus[3], c)
+	}
+	z[0] = z[0]>>1 | z[1]<<63
+	z[1] = z[1]>>1 | z[2]<<63
+	z[2] = z[2]>>1 | z[3]<<63
+	z[3] = z[3]>>1 | c<<63
+}
+
+// fpSub sets z = x - y
+func fpSub(z, x, y *Fp) {
+	montSub((*[4]uint64)(z), (*[4]uint64)(x), (*[4]uint64)(y), &fpField)
+}
+
+// fpNeg sets z = -x
+func fpNeg(z, x *Fp) {
+	montNeg((*[4]uint64)(z), (*[4]uint64)(x), &fpField)
+}
+
+// fpMulGeneric is the portable CIOS Montgomery multiplication
+func fpMulGeneric(z, x, y *Fp) {
+	montMulGeneric((*[4]uint64)(z), (*[4]uint64)(x), (*[4]uint64)(y), &fpField)
+}
+
+// fpExp sets z = x^e where e is given as little-endian 64-bit limbs
+func fpExp(z, x *Fp, e []uint64) {
+	montExp((*[4]uint64)(z), (*[4]uint64)(x), e, &fpField)
+}
+
+// fpInverse sets z = x⁻¹ (or 0 if x == 0)
+func fpInverse(z, x *Fp) {
+	montInverse((*[4]uint64)(z), (*[4]uint64)(x), &fpField)
+}
+
+// fpBatchInverse sets z[i] = x[i]⁻¹ (or 0 if x[i] == 0) for every i, using
+// one inversion in total. z and x may be the same slice.
+func fpBatchInverse(z, x []Fp) {
+	montBatchInverse(z, x, &fpField)
+}
+
+// fpSqrt sets z to a square root of x and reports whether x is a square.
+// Since p ≡ 3 mod 4 the candidate is x^((p+1)/4); z is unspecified if the
+// candidate does not square back to x.
+func fpSqrt(z, x *Fp) bool {
+	var t, t2 Fp
+	fpExp(&t, x, fpPPlus1Over4[:])
+	fpSquare(&t2, &t)
+	*z = t
+	return t2 == *x
+}
+
+// fpSgn0 returns the parity of the canonical value of x, the sgn0 of
+// RFC 9380
+func fpSgn0(x *Fp) uint64 {
+	var t Fp
+	fpFromMont(&t, x)
+	return t[0] & 1
 }
 
 // ============================================================================
@@ -164,128 +688,317 @@ func (f *Fp) BigInt() *big.Int {
 // Fp2 represents an element in Fp2 = Fp[u]/(u²+1)
 // Represented as a + b*u where a, b ∈ Fp
 type Fp2 struct {
-	a, b *big.Int //a + b*u
+	a, b Fp // a + b*u
 }
 
+// fp2One is 1 in Fp2
+var fp2One = Fp2{a: fpOne}
+
 // NewFp2 creates a new Fp2 element
 func NewFp2(a, b *big.Int) *Fp2 {
-	return &Fp2{
-		a: new(big.Int).Mod(a, P),
-		b: new(big.Int).Mod(b, P),
-	}
+	z := new(Fp2)
+	fpSetBig(&z.a, a)
+	fpSetBig(&z.b, b)
+	return z
 }
 
 // Copy creates a deep copy
 func (f *Fp2) Copy() *Fp2 {
-	return &Fp2{
-		a: new(big.Int).Set(f.a),
-		b: new(big.Int).Set(f.b),
-	}
+	z := *f
+	return &z
 }
 
 // Add computes f + g in Fp2
 func (f *Fp2) Add(g *Fp2) *Fp2 {
-	a := new(big.Int).Add(f.a, g.a)
-	a.Mod(a, P)
-	b := new(big.Int).Add(f.b, g.b)
-	b.Mod(b, P)
-	return &Fp2{a: a, b: b}
+	z := new(Fp2)
+	fp2Add(z, f, g)
+	return z
 }
 
 // Sub computes f - g in Fp2
 func (f *Fp2) Sub(g *Fp2) *Fp2 {
-	a := new(big.Int).Sub(f.a, g.a)
-	a.Mod(a, P)
-	b := new(big.Int).Sub(f.b, g.b)
-	b.Mod(b, P)
-	return &Fp2{a: a, b: b}
+	z := new(Fp2)
+	fp2Sub(z, f, g)
+	return z
 }
 
 // Mul computes f * g in Fp2 using Karatsuba multiplication
 // (a + bu)(c + du) = (ac - bd) + (ad + bc)u, where u² = -1
 func (f *Fp2) Mul(g *Fp2) *Fp2 {
-	// Karatsuba: (a+bu)(c+du) = ac - bd + ((a+b)(c+d) - ac - bd)u
-	ac := new(big.Int).Mul(f.a, g.a)
-	bd := new(big.Int).Mul(f.b, g.b)
-
-	aPlusB := new(big.Int).Add(f.a, f.b)
-	cPlusD := new(big.Int).Add(g.a, g.b)
-	adPlusBc := new(big.Int).Mul(aPlusB, cPlusD)
-	adPlusBc.Sub(adPlusBc, ac).Sub(adPlusBc, bd)
-
-	// Real part: ac - bd (since u² = -1)
-	realPart := new(big.Int).Sub(ac, bd)
-	realPart.Mod(realPart, P)
-
-	// Imaginary part: ad + bc
-	imagPart := adPlusBc.Mod(adPlusBc, P)
-	return &Fp2{a: realPart, b: imagPart}
+	z := new(Fp2)
+	fp2Mul(z, f, g)
+	return z
 }
 
 // Square computes f² in Fp2 optimized
 func (f *Fp2) Square() *Fp2 {
-	// (a + bu)² = (a² - b²) + 2ab*u
-	a2 := new(big.Int).Mul(f.a, f.a)
-	b2 := new(big.Int).Mul(f.b, f.b)
-	realPart := new(big.Int).Sub(a2, b2)
-	realPart.Mod(realPart, P)
-
-	imagPart := new(big.Int).Mul(f.a, f.b)
-	imagPart.Lsh(imagPart, 1) // *2
-	imagPart.Mod(imagPart, P)
-
-	return &Fp2{a: realPart, b: imagPart}
+	z := new(Fp2)
+	fp2Square(z, f)
+	return z
 }
 
 // Inverse computes f⁻¹ in Fp2
 func (f *Fp2) Inverse() *Fp2 {
-	if f.IsZero() {
-		return &Fp2{a: big.NewInt(0), b: big.NewInt(0)}
-	}
-	// 1/(a+bu) = (a-bu)/(a²+b²)
-	a2 := new(big.Int).Mul(f.a, f.a)
-	b2 := new(big.Int).Mul(f.b, f.b)
-	norm := new(big.Int).Add(a2, b2)
-	norm.Mod(norm, P)
-
-	normInv := new(big.Int).ModInverse(norm, P)
-
-	realPart := new(big.Int).Mul(f.a, normInv)
-	realPart.Mod(realPart, P)
-
-	imagPart := new(big.Int).Mul(f.b, normInv)
-	imagPart.Neg(imagPart)
-	imagPart.Mod(imagPart, P)
+	z := new(Fp2)
+	fp2Inverse(z, f)
+	return z
+}
 
-	return &Fp2{a: realPart, b: imagPart}
+// BatchInverseFp2 returns the inverses of xs with a single Fp2 inversion
+// and three multiplications per element (Montgomery's trick). Zero elements
+// are skipped and map to zero, as in Inverse.
+func BatchInverseFp2(xs []*Fp2) []*Fp2 {
+	vals := make([]Fp2, len(xs))
+	for i, x := range xs {
+		vals[i] = *x
+	}
+	fp2BatchInverse(vals, vals)
+	out := make([]*Fp2, len(xs))
+	for i := range vals {
+		out[i] = &vals[i]
+	}
+	return out
 }
 
 // Neg computes -f in Fp2
 func (f *Fp2) Neg() *Fp2 {
-	a := new(big.Int).Neg(f.a)
-	a.Mod(a, P)
-	b := new(big.Int).Neg(f.b)
-	b.Mod(b, P)
-	return &Fp2{a: a, b: b}
+	z := new(Fp2)
+	fp2Neg(z, f)
+	return z
 }
 
 // MulScalar multiplies by a scalar from Fp
 func (f *Fp2) MulScalar(s *big.Int) *Fp2 {
-	a := new(big.Int).Mul(f.a, s)
-	a.Mod(a, P)
-	b := new(big.Int).Mul(f.b, s)
-	b.Mod(b, P)
-	return &Fp2{a: a, b: b}
+	var t Fp
+	fpSetBig(&t, s)
+	z := new(Fp2)
+	fp2MulFp(z, f, &t)
+	return z
 }
 
 // IsZero returns true if f == 0
 func (f *Fp2) IsZero() bool {
-	return f.a.Sign() == 0 && f.b.Sign() == 0
+	return f.a.IsZero() && f.b.IsZero()
 }
 
 // Equal returns true if f == g
 func (f *Fp2) Equal(g *Fp2) bool {
-	return f.a.Cmp(g.a) == 0 && f.b.Cmp(g.b) == 0
+	return *f == *g
+}
+
+// Sqrt returns a square root of f and whether f is a square; the other
+// root is its negation
+func (f *Fp2) Sqrt() (*Fp2, bool) {
+	z := new(Fp2)
+	if !fp2Sqrt(z, f) {
+		return nil, false
+	}
+	return z, true
+}
+
+// IsSquare reports whether f is a square in Fp2. An element is a square
+// exactly when its norm a² + b² is a square in Fp, which costs one Fp
+// exponentiation instead of a full square root.
+func (f *Fp2) IsSquare() bool {
+	var n, t Fp
+	fpSquare(&n, &f.a)
+	fpSquare(&t, &f.b)
+	fpAdd(&n, &n, &t)
+	return n.Legendre() >= 0
+}
+
+// fp2Add sets z = x + y
+func fp2Add(z, x, y *Fp2) {
+	fpAdd(&z.a, &x.a, &y.a)
+	fpAdd(&z.b, &x.b, &y.b)
+}
+
+// fp2Double sets z = 2x
+func fp2Double(z, x *Fp2) {
+	fpDouble(&z.a, &x.a)
+	fpDouble(&z.b, &x.b)
+}
+
+// fp2Halve sets z = x/2
+func fp2Halve(z, x *Fp2) {
+	fpHalve(&z.a, &x.a)
+	fpHalve(&z.b, &x.b)
+}
+
+// fp2Sub sets z = x - y
+func fp2Sub(z, x, y *Fp2) {
+	fpSub(&z.a, &x.a, &y.a)
+	fpSub(&z.b, &x.b, &y.b)
+}
+
+// fp2Neg sets z = -x
+func fp2Neg(z, x *Fp2) {
+	fpNeg(&z.a, &x.a)
+	fpNeg(&z.b, &x.b)
+}
+
+// fp2Conjugate sets z = a - bu for x = a + bu
+func fp2Conjugate(z, x *Fp2) {
+	z.a = x.a
+	fpNeg(&z.b, &x.b)
+}
+
+// fp2Mul sets z = x * y
+func fp2Mul(z, x, y *Fp2) {
+	// Karatsuba: (a+bu)(c+du) = ac - bd + ((a+b)(c+d) - ac - bd)u
+	var ac, bd, t0, t1 Fp
+	fpMul(&ac, &x.a, &y.a)
+	fpMul(&bd, &x.b, &y.b)
+	fpAdd(&t0, &x.a, &x.b)
+	fpAdd(&t1, &y.a, &y.b)
+	fpMul(&t0, &t0, &t1)
+	fpSub(&t0, &t0, &ac)
+	fpSub(&z.b, &t0, &bd)
+	fpSub(&z.a, &ac, &bd)
+}
+
+// fp2Square sets z = x²
+func fp2Square(z, x *Fp2) {
+	// (a + bu)² = (a+b)(a-b) + 2ab*u
+	var t0, t1, t2 Fp
+	fpAdd(&t0, &x.a, &x.b)
+	fpSub(&t1, &x.a, &x.b)
+	fpMul(&t2, &x.a, &x.b)
+	fpMul(&z.a, &t0, &t1)
+	fpDouble(&z.b, &t2)
+}
+
+// fp2MulFp sets z = x * s for s ∈ Fp
+func fp2MulFp(z, x *Fp2, s *Fp) {
+	fpMul(&z.a, &x.a, s)
+	fpMul(&z.b, &x.b, s)
+}
+
+// fp2Inverse sets z = x⁻¹ (or 0 if x == 0)
+func fp2Inverse(z, x *Fp2) {
+	// 1/(a+bu) = (a-bu)/(a²+b²)
+	var t0, t1 Fp
+	fpSquare(&t0, &x.a)
+	fpSquare(&t1, &x.b)
+	fpAdd(&t0, &t0, &t1)
+	fpInverse(&t1, &t0)
+	fpMul(&z.a, &x.a, &t1)
+	fpMul(&t0, &x.b, &t1)
+	fpNeg(&z.b, &t0)
+}
+
+// fp2BatchInverse sets z[i] = x[i]⁻¹ (or 0 if x[i] == 0) for every i, using
+// one inversion in total. z and x may be the same slice.
+func fp2BatchInverse(z, x []Fp2) {
+	// prefix[i] is the product of the non-zero x[j] with j < i
+	prefix := make([]Fp2, len(x))
+	var acc Fp2
+	acc.a = fpOne
+	for i := range x {
+		prefix[i] = acc
+		if !x[i].IsZero() {
+			fp2Mul(&acc, &acc, &x[i])
+		}
+	}
+
+	// acc holds the inverse of the product of x[0..i] on each step down
+	fp2Inverse(&acc, &acc)
+	for i := len(x) - 1; i >= 0; i-- {
+		if x[i].IsZero() {
+			z[i] = Fp2{}
+			continue
+		}
+		var inv Fp2
+		fp2Mul(&inv, &acc, &prefix[i])
+		fp2Mul(&acc, &acc, &x[i])
+		z[i] = inv
+	}
+}
+
+// fp2Exp sets z = x^e where e is given as little-endian 64-bit limbs
+func fp2Exp(z, x *Fp2, e []uint64) {
+	base := *x
+	result := fp2One
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			fp2Square(&result, &result)
+			if (e[i]>>uint(j))&1 == 1 {
+				fp2Mul(&result, &result, &base)
+			}
+		}
+	}
+	*z = result
+}
+
+// fp2Sqrt sets z to a square root of x and reports whether x is a square,
+// using Algorithm 9 of Adj and Rodríguez-Henríquez, "Square root computation
+// over even extension fields", for p ≡ 3 mod 4. z is unspecified if x is
+// not a square.
+func fp2Sqrt(z, x *Fp2) bool {
+	if x.IsZero() {
+		*z = Fp2{}
+		return true
+	}
+
+	// a1 = x^((p-3)/4), α = a1²·x = x^((p-1)/2), x0 = a1·x
+	var a1, alpha, x0, t Fp2
+	fp2Exp(&a1, x, fpPMinus3Over4[:])
+	fp2Square(&alpha, &a1)
+	fp2Mul(&alpha, &alpha, x)
+	fp2Mul(&x0, &a1, x)
+
+	// α^(p+1) = N(x)^((p-1)/2) is -1 exactly when x is not a square
+	var minusOne Fp
+	fpNeg(&minusOne, &fpOne)
+	fp2Conjugate(&t, &alpha)
+	fp2Mul(&t, &t, &alpha)
+	if t.a == minusOne && t.b.IsZero() {
+		return false
+	}
+
+	if alpha.a == minusOne && alpha.b.IsZero() {
+		// x0² = -x, so multiply by u where u² = -1
+		z.a, z.b = x0.b, x0.a
+		fpNeg(&z.a, &z.a)
+	} else {
+		// b = (1 + α)^((p-1)/2)
+		fp2Add(&t, &alpha, &fp2One)
+		fp2Exp(&t, &t, fpPMinus1Over2[:])
+		fp2Mul(z, &t, &x0)
+	}
+
+	var check Fp2
+	fp2Square(&check, z)
+	return check == *x
+}
+
+// fp2Sgn0 returns sgn0 of RFC 9380 for a + bu: the parity of a, or of b
+// when a = 0
+func fp2Sgn0(x *Fp2) uint64 {
+	s := fpSgn0(&x.a)
+	if x.a.IsZero() {
+		s = fpSgn0(&x.b)
+	}
+	return s
+}
+
+// fp2MulByNonResidue sets z = x * ξ where ξ = 9+u
+func fp2MulByNonResidue(z, x *Fp2) {
+	// (a + bu)(9 + u) = (9a - b) + (a + 9b)u
+	var t0, t1 Fp
+	fpDouble(&t0, &x.a)
+	fpDouble(&t0, &t0)
+	fpDouble(&t0, &t0)
+	fpAdd(&t0, &t0, &x.a)
+	fpSub(&t0, &t0, &x.b)
+
+	fpDouble(&t1, &x.b)
+	fpDouble(&t1, &t1)
+	fpDouble(&t1, &t1)
+	fpAdd(&t1, &t1, &x.b)
+	fpAdd(&t1, &t1, &x.a)
+
+	z.a, z.b = t0, t1
 }
 
 // ============================================================================
@@ -295,117 +1008,60 @@ func (f *Fp2) Equal(g *Fp2) bool {
 // Fp6 represents an element in Fp6 = Fp2[v]/(v³-ξ) where ξ = u+9
 // Represented as c0 + c1*v + c2*v² where c0, c1, c2 ∈ Fp2
 type Fp6 struct {
-	c0, c1, c2 *Fp2
+	c0, c1, c2 Fp2
 }
 
 // NewFp6 creates a new Fp6 element
 func NewFp6(c0, c1, c2 *Fp2) *Fp6 {
-	return &Fp6{c0: c0, c1: c1, c2: c2}
+	return &Fp6{c0: *c0, c1: *c1, c2: *c2}
 }
 
 // Copy creates a deep copy
 func (f *Fp6) Copy() *Fp6 {
-	return &Fp6{
-		c0: f.c0.Copy(),
-		c1: f.c1.Copy(),
-		c2: f.c2.Copy(),
-	}
+	z := *f
+	return &z
 }
 
 // Add computes f + g in Fp6
 func (f *Fp6) Add(g *Fp6) *Fp6 {
-	return &Fp6{
-		c0: f.c0.Add(g.c0),
-		c1: f.c1.Add(g.c1),
-		c2: f.c2.Add(g.c2),
-	}
+	z := new(Fp6)
+	fp6Add(z, f, g)
+	return z
 }
 
 // Sub computes f - g in Fp6
 func (f *Fp6) Sub(g *Fp6) *Fp6 {
-	return &Fp6{
-		c0: f.c0.Sub(g.c0),
-		c1: f.c1.Sub(g.c1),
-		c2: f.c2.Sub(g.c2),
-	}
-}
-
-// mulByNonResidue multiplies by the non-residue ξ = u+9
-func mulByNonResidue(f *Fp2) *Fp2 {
-	// (a + bu)(u + 9) = (9a - b) + (a + 9b)u
-	nine := big.NewInt(9)
-	realPart := new(big.Int).Mul(f.a, nine)
-	realPart.Sub(realPart, f.b)
-	realPart.Mod(realPart, P)
-
-	imagPart := new(big.Int).Add(f.a, new(big.Int).Mul(f.b, nine))
-	imagPart.Mod(imagPart, P)
-
-	return &Fp2{a: realPart, b: imagPart}
+	z := new(Fp6)
+	fp6Sub(z, f, g)
+	return z
 }
 
-// Mul computes f * g in Fp6 using Karatsuba
 // Mul computes f * g in Fp6 using Karatsuba
 func (f *Fp6) Mul(g *Fp6) *Fp6 {
-	// Use Karatsuba multiplication for efficiency
-	a := f.c0.Mul(g.c0)
-	b := f.c1.Mul(g.c1)
-	c := f.c2.Mul(g.c2)
-
-	t0 := f.c1.Add(f.c2)
-	t1 := g.c1.Add(g.c2)
-	t0 = t0.Mul(t1)
-	t0 = t0.Sub(b).Sub(c)
-	t0 = mulByNonResidue(t0)
-	c0 := a.Add(t0)
-
-	t0 = f.c0.Add(f.c1)
-	t1 = g.c0.Add(g.c1)
-	t0 = t0.Mul(t1)
-	t0 = t0.Sub(a).Sub(b)
-	c1 := mulByNonResidue(c).Add(t0)
-
-	t0 = f.c0.Add(f.c2)
-	t1 = g.c0.Add(g.c2)
-	t0 = t0.Mul(t1)
-	c2 := t0.Sub(a).Sub(c).Add(b)
-
-	return &Fp6{c0: c0, c1: c1, c2: c2}
+	z := new(Fp6)
+	fp6Mul(z, f, g)
+	return z
 }
 
 // Square computes f² in Fp6
 func (f *Fp6) Square() *Fp6 {
-	return f.Mul(f)
+	z := new(Fp6)
+	fp6Square(z, f)
+	return z
 }
 
 // Inverse computes f⁻¹ in Fp6
 func (f *Fp6) Inverse() *Fp6 {
-	// Use the norm formula for sextic extensions
-	c0 := f.c0.Square().Sub(mulByNonResidue(f.c1.Mul(f.c2)))
-	c1 := mulByNonResidue(f.c2.Square()).Sub(f.c0.Mul(f.c1))
-	c2 := f.c1.Square().Sub(f.c0.Mul(f.c2))
-
-	t := f.c2.Mul(c1)
-	t = mulByNonResidue(t)
-	t = t.Add(f.c1.Mul(c2))
-	t = mulByNonResidue(t)
-	t = t.Add(f.c0.Mul(c0))
-	t = t.Inverse()
-
-	return &Fp6{
-		c0: c0.Mul(t),
-		c1: c1.Mul(t),
-		c2: c2.Mul(t),
-	}
+	z := new(Fp6)
+	fp6Inverse(z, f)
+	return z
 }
 
 // Neg computes -f in Fp6
 func (f *Fp6) Neg() *Fp6 {
-	return &Fp6{
-		c0: f.c0.Neg(),
-		c1: f.c1.Neg(),
-		c2: f.c2.Neg(),
-	}
+	z := new(Fp6)
+	fp6Neg(z, f)
+	return z
 }
 
 // IsZero returns true if f == 0
@@ -413,6 +1069,172 @@ func (f *Fp6) IsZero() bool {
 	return f.c0.IsZero() && f.c1.IsZero() && f.c2.IsZero()
 }
 
+// fp6Add sets z = x + y
+func fp6Add(z, x, y *Fp6) {
+	fp2Add(&z.c0, &x.c0, &y.c0)
+	fp2Add(&z.c1, &x.c1, &y.c1)
+	fp2Add(&z.c2, &x.c2, &y.c2)
+}
+
+// fp6Sub sets z = x - y
+func fp6Sub(z, x, y *Fp6) {
+	fp2Sub(&z.c0, &x.c0, &y.c0)
+	fp2Sub(&z.c1, &x.c1, &y.c1)
+	fp2Sub(&z.c2, &x.c2, &y.c2)
+}
+
+// fp6Neg sets z = -x
+func fp6Neg(z, x *Fp6) {
+	fp2Neg(&z.c0, &x.c0)
+	fp2Neg(&z.c1, &x.c1)
+	fp2Neg(&z.c2, &x.c2)
+}
+
+// fp6MulByV sets z = x * v, shifting coefficients since v³ = ξ
+func fp6MulByV(z, x *Fp6) {
+	var t Fp2
+	fp2MulByNonResidue(&t, &x.c2)
+	z.c2 = x.c1
+	z.c1 = x.c0
+	z.c0 = t
+}
+
+// fp6Mul sets z = x * y
+func fp6Mul(z, x, y *Fp6) {
+	// Use Karatsuba multiplication for efficiency
+	var a, b, c, t0, t1, c0, c1, c2 Fp2
+	fp2Mul(&a, &x.c0, &y.c0)
+	fp2Mul(&b, &x.c1, &y.c1)
+	fp2Mul(&c, &x.c2, &y.c2)
+
+	// c0 = a + ξ((x1+x2)(y1+y2) - b - c)
+	fp2Add(&t0, &x.c1, &x.c2)
+	fp2Add(&t1, &y.c1, &y.c2)
+	fp2Mul(&t0, &t0, &t1)
+	fp2Sub(&t0, &t0, &b)
+	fp2Sub(&t0, &t0, &c)
+	fp2MulByNonResidue(&t0, &t0)
+	fp2Add(&c0, &a, &t0)
+
+	// c1 = (x0+x1)(y0+y1) - a - b + ξc
+	fp2Add(&t0, &x.c0, &x.c1)
+	fp2Add(&t1, &y.c0, &y.c1)
+	fp2Mul(&t0, &t0, &t1)
+	fp2Sub(&t0, &t0, &a)
+	fp2Sub(&t0, &t0, &b)
+	fp2MulByNonResidue(&t1, &c)
+	fp2Add(&c1, &t0, &t1)
+
+	// c2 = (x0+x2)(y0+y2) - a - c + b
+	fp2Add(&t0, &x.c0, &x.c2)
+	fp2Add(&t1, &y.c0, &y.c2)
+	fp2Mul(&t0, &t0, &t1)
+	fp2Sub(&t0, &t0, &a)
+	fp2Sub(&t0, &t0, &c)
+	fp2Add(&c2, &t0, &b)
+
+	z.c0, z.c1, z.c2 = c0, c1, c2
+}
+
+// fp6MulBy01 sets z = x * (c0 + c1·v) with Karatsuba, using 5 Fp2
+// multiplications instead of the 6 of fp6Mul
+func fp6MulBy01(z, x *Fp6, c0, c1 *Fp2) {
+	var a, b, t0, t1, t2, tmp Fp2
+	fp2Mul(&a, &x.c0, c0)
+	fp2Mul(&b, &x.c1, c1)
+
+	// t0 = a + ξ·c1·x2
+	fp2Add(&tmp, &x.c1, &x.c2)
+	fp2Mul(&t0, c1, &tmp)
+	fp2Sub(&t0, &t0, &b)
+	fp2MulByNonResidue(&t0, &t0)
+	fp2Add(&t0, &t0, &a)
+
+	// t1 = c0·x1 + c1·x0
+	fp2Add(&t1, c0, c1)
+	fp2Add(&tmp, &x.c0, &x.c1)
+	fp2Mul(&t1, &t1, &tmp)
+	fp2Sub(&t1, &t1, &a)
+	fp2Sub(&t1, &t1, &b)
+
+	// t2 = c0·x2 + b
+	fp2Add(&tmp, &x.c0, &x.c2)
+	fp2Mul(&t2, c0, &tmp)
+	fp2Sub(&t2, &t2, &a)
+	fp2Add(&t2, &t2, &b)
+
+	z.c0, z.c1, z.c2 = t0, t1, t2
+}
+
+// fp6MulByFp2 sets z = x * c for c in Fp2
+func fp6MulByFp2(z, x *Fp6, c *Fp2) {
+	fp2Mul(&z.c0, &x.c0, c)
+	fp2Mul(&z.c1, &x.c1, c)
+	fp2Mul(&z.c2, &x.c2, c)
+}
+
+// fp6Square sets z = x² using the Chung-Hasan SQR2 formulas
+func fp6Square(z, x *Fp6) {
+	var s0, s1, s2, s3, s4, t Fp2
+	fp2Square(&s0, &x.c0)
+	fp2Mul(&s1, &x.c0, &x.c1)
+	fp2Double(&s1, &s1)
+	fp2Sub(&t, &x.c0, &x.c1)
+	fp2Add(&t, &t, &x.c2)
+	fp2Square(&s2, &t)
+	fp2Mul(&s3, &x.c1, &x.c2)
+	fp2Double(&s3, &s3)
+	fp2Square(&s4, &x.c2)
+
+	// c0 = s0 + ξs3
+	fp2MulByNonResidue(&t, &s3)
+	fp2Add(&z.c0, &s0, &t)
+	// c2 = s1 + s2 + s3 - s0 - s4
+	fp2Add(&z.c2, &s1, &s2)
+	fp2Add(&z.c2, &z.c2, &s3)
+	fp2Sub(&z.c2, &z.c2, &s0)
+	fp2Sub(&z.c2, &z.c2, &s4)
+	// c1 = s1 + ξs4
+	fp2MulByNonResidue(&t, &s4)
+	fp2Add(&z.c1, &s1, &t)
+}
+
+// fp6Inverse sets z = x⁻¹
+func fp6Inverse(z, x *Fp6) {
+	// Use the norm formula for sextic extensions
+	var c0, c1, c2, t0, t1 Fp2
+
+	// c0 = x0² - ξx1x2
+	fp2Square(&c0, &x.c0)
+	fp2Mul(&t0, &x.c1, &x.c2)
+	fp2MulByNonResidue(&t0, &t0)
+	fp2Sub(&c0, &c0, &t0)
+
+	// c1 = ξx2² - x0x1
+	fp2Square(&c1, &x.c2)
+	fp2MulByNonResidue(&c1, &c1)
+	fp2Mul(&t0, &x.c0, &x.c1)
+	fp2Sub(&c1, &c1, &t0)
+
+	// c2 = x1² - x0x2
+	fp2Square(&c2, &x.c1)
+	fp2Mul(&t0, &x.c0, &x.c2)
+	fp2Sub(&c2, &c2, &t0)
+
+	// t = x0c0 + ξ(x2c1 + x1c2)
+	fp2Mul(&t0, &x.c2, &c1)
+	fp2Mul(&t1, &x.c1, &c2)
+	fp2Add(&t0, &t0, &t1)
+	fp2MulByNonResidue(&t0, &t0)
+	fp2Mul(&t1, &x.c0, &c0)
+	fp2Add(&t0, &t0, &t1)
+	fp2Inverse(&t0, &t0)
+
+	fp2Mul(&z.c0, &c0, &t0)
+	fp2Mul(&z.c1, &c1, &t0)
+	fp2Mul(&z.c2, &c2, &t0)
+}
+
 // ============================================================================
 // Fp12 - Dodecic Extension Field Element
 // ============================================================================
@@ -420,109 +1242,82 @@ func (f *Fp6) IsZero() bool {
 // Fp12 represents an element in Fp12 = Fp6[w]/(w²-v)
 // Represented as c0 + c1*w where c0, c1 ∈ Fp6
 type Fp12 struct {
-	c0, c1 *Fp6
+	c0, c1 Fp6
 }
 
 // NewFp12 creates a new Fp12 element
 func NewFp12(c0, c1 *Fp6) *Fp12 {
-	return &Fp12{c0: c0, c1: c1}
+	return &Fp12{c0: *c0, c1: *c1}
+}
+
+// fp12One returns the multiplicative identity of Fp12
+func fp12One() *Fp12 {
+	z := new(Fp12)
+	z.c0.c0.a = fpOne
+	return z
 }
 
 // Copy creates a deep copy
 func (f *Fp12) Copy() *Fp12 {
-	return &Fp12{
-		c0: f.c0.Copy(),
-		c1: f.c1.Copy(),
-	}
+	z := *f
+	return &z
 }
 
 // Add computes f + g in Fp12
 func (f *Fp12) Add(g *Fp12) *Fp12 {
-	return &Fp12{
-		c0: f.c0.Add(g.c0),
-		c1: f.c1.Add(g.c1),
-	}
+	z := new(Fp12)
+	fp6Add(&z.c0, &f.c0, &g.c0)
+	fp6Add(&z.c1, &f.c1, &g.c1)
+	return z
 }
 
 // Sub computes f - g in Fp12
 func (f *Fp12) Sub(g *Fp12) *Fp12 {
-	return &Fp12{
-		c0: f.c0.Sub(g.c0),
-		c1: f.c1.Sub(g.c1),
-	}
+	z := new(Fp12)
+	fp6Sub(&z.c0, &f.c0, &g.c0)
+	fp6Sub(&z.c1, &f.c1, &g.c1)
+	return z
 }
 
 // Mul computes f * g in Fp12
 func (f *Fp12) Mul(g *Fp12) *Fp12 {
-	// (a + bw)(c + dw) = (ac + bd*v) + (ad + bc)w where w² = v
-	ac := f.c0.Mul(g.c0)
-	bd := f.c1.Mul(g.c1)
-
-	// bd*v: multiply bd by v (shift coefficients)
-	bdv := &Fp6{
-		c0: mulByNonResidue(bd.c2),
-		c1: bd.c0,
-		c2: bd.c1,
-	}
-
-	c0 := ac.Add(bdv)
-
-	// (a+b)(c+d) - ac - bd
-	t0 := f.c0.Add(f.c1)
-	t1 := g.c0.Add(g.c1)
-	c1 := t0.Mul(t1).Sub(ac).Sub(bd)
+	z := new(Fp12)
+	fp12Mul(z, f, g)
+	return z
+}
 
-	return &Fp12{c0: c0, c1: c1}
+// MulBy034 computes f * (c0 + c3·w + c4·vw), a product with a sparse
+// element such as a Miller loop line, faster than Mul
+func (f *Fp12) MulBy034(c0, c3, c4 *Fp2) *Fp12 {
+	z := new(Fp12)
+	fp12MulBy034(z, f, c0, c3, c4)
+	return z
 }
 
 // Square computes f² in Fp12
 func (f *Fp12) Square() *Fp12 {
-	return f.Mul(f)
+	z := new(Fp12)
+	fp12Square(z, f)
+	return z
 }
 
 // Inverse computes f⁻¹ in Fp12
 func (f *Fp12) Inverse() *Fp12 {
-	// 1/(a+bw) = (a-bw)/(a²-b²v)
-	t0 := f.c0.Square()
-	t1 := f.c1.Square()
-	// t1*v
-	t1v := &Fp6{
-		c0: mulByNonResidue(t1.c2),
-		c1: t1.c0,
-		c2: t1.c1,
-	}
-	t0 = t0.Sub(t1v)
-	t0 = t0.Inverse()
-
-	return &Fp12{
-		c0: f.c0.Mul(t0),
-		c1: f.c1.Neg().Mul(t0),
-	}
+	z := new(Fp12)
+	fp12Inverse(z, f)
+	return z
 }
 
 // Exp computes f^e in Fp12 using square-and-multiply
 func (f *Fp12) Exp(e *big.Int) *Fp12 {
-	result := &Fp12{
-		c0: &Fp6{
-			c0: &Fp2{a: big.NewInt(1), b: big.NewInt(0)},
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
-		c1: &Fp6{
-			c0: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
-	}
-
-	base := f.Copy()
-	for i := 0; i < e.BitLen(); i++ {
+	z := fp12One()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		fp12Square(z, z)
 		if e.Bit(i) == 1 {
-			result = result.Mul(base)
+			fp12Mul(z, z, f)
 		}
-		base = base.Square()
 	}
-	return result
+	return z
 }
 
 // IsZero returns true if f == 0
@@ -532,10 +1327,79 @@ func (f *Fp12) IsZero() bool {
 
 // IsOne returns true if f == 1
 func (f *Fp12) IsOne() bool {
-	one := &Fp2{a: big.NewInt(1), b: big.NewInt(0)}
-	zero := &Fp2{a: big.NewInt(0), b: big.NewInt(0)}
-	return f.c0.c0.Equal(one) && f.c0.c1.IsZero() && f.c0.c2.IsZero() &&
-		f.c1.c0.Equal(zero) && f.c1.c1.IsZero() && f.c1.c2.IsZero()
+	return *f == *fp12One()
+}
+
+// fp12Mul sets z = x * y
+func fp12Mul(z, x, y *Fp12) {
+	// (a + bw)(c + dw) = (ac + bd*v) + ((a+b)(c+d) - ac - bd)w where w² = v
+	var ac, bd, t0, t1 Fp6
+	fp6Mul(&ac, &x.c0, &y.c0)
+	fp6Mul(&bd, &x.c1, &y.c1)
+	fp6Add(&t0, &x.c0, &x.c1)
+	fp6Add(&t1, &y.c0, &y.c1)
+	fp6Mul(&t0, &t0, &t1)
+	fp6Sub(&t0, &t0, &ac)
+	fp6Sub(&z.c1, &t0, &bd)
+	fp6MulByV(&bd, &bd)
+	fp6Add(&z.c0, &ac, &bd)
+}
+
+// fp12MulBy034 sets z = x * (c0 + c3·w + c4·vw), the shape of a Miller
+// loop line. The sparse Karatsuba costs 13 Fp2 multiplications against 18
+// for fp12Mul.
+func fp12MulBy034(z, x *Fp12, c0, c3, c4 *Fp2) {
+	var a, b, d Fp6
+	var d0 Fp2
+	fp6MulByFp2(&a, &x.c0, c0)
+	fp6MulBy01(&b, &x.c1, c3, c4)
+
+	// z1 = (x0 + x1)(c0 + c3 + c4·v) - a - b
+	fp2Add(&d0, c0, c3)
+	fp6Add(&d, &x.c0, &x.c1)
+	fp6MulBy01(&d, &d, &d0, c4)
+	fp6Sub(&d, &d, &a)
+	fp6Sub(&z.c1, &d, &b)
+
+	// z0 = a + b·v
+	fp6MulByV(&b, &b)
+	fp6Add(&z.c0, &a, &b)
+}
+
+// fp12Square sets z = x² using complex squaring
+func fp12Square(z, x *Fp12) {
+	// (a + bw)² = (a+b)(a+bv) - ab - abv + 2abw
+	var ab, t0, t1 Fp6
+	fp6Mul(&ab, &x.c0, &x.c1)
+	fp6Add(&t0, &x.c0, &x.c1)
+	fp6MulByV(&t1, &x.c1)
+	fp6Add(&t1, &t1, &x.c0)
+	fp6Mul(&t0, &t0, &t1)
+	fp6Sub(&t0, &t0, &ab)
+	fp6MulByV(&t1, &ab)
+	fp6Sub(&z.c0, &t0, &t1)
+	fp6Add(&z.c1, &ab, &ab)
+}
+
+// fp12Inverse sets z = x⁻¹
+func fp12Inverse(z, x *Fp12) {
+	// 1/(a+bw) = (a-bw)/(a²-b²v)
+	var t0, t1 Fp6
+	fp6Square(&t0, &x.c0)
+	fp6Square(&t1, &x.c1)
+	fp6MulByV(&t1, &t1)
+	fp6Sub(&t0, &t0, &t1)
+	fp6Inverse(&t0, &t0)
+
+	fp6Mul(&z.c0, &x.c0, &t0)
+	fp6Mul(&z.c1, &x.c1, &t0)
+	fp6Neg(&z.c1, &z.c1)
+}
+
+// fp12Conjugate sets z = a - bw for x = a + bw, which is x^(p⁶)
+func fp12Conjugate(z, x *Fp12) {
+	z.c0 = x.c0
+	fp6Neg(&z.c1, &x.c1)
 }
 
 // ============================================================================
@@ -544,16 +1408,15 @@ func (f *Fp12) IsOne() bool {
 
 // G1 represents a point on the BN128 curve over Fp
 type G1 struct {
-	X, Y *big.Int
+	X, Y Fp
 	// We use affine coordinates; point at infinity represented by X=Y=0
 }
 
 // NewG1 creates a new G1 point
 func NewG1(x, y *big.Int) (*G1, error) {
-	p := &G1{
-		X: new(big.Int).Set(x),
-		Y: new(big.Int).Set(y),
-	}
+	p := new(G1)
+	fpSetBig(&p.X, x)
+	fpSetBig(&p.Y, y)
 
 	if !p.IsOnCurve() {
 		return nil, ErrInvalidPoint
@@ -564,23 +1427,21 @@ func NewG1(x, y *big.Int) (*G1, error) {
 
 // // G1Generator returns the G1 generator point
 func G1Generator() *G1 {
-	return &G1{
-		X: new(big.Int).Set(GeneratorG1X),
-		Y: new(big.Int).Set(GeneratorG1Y),
-	}
+	p := new(G1)
+	fpSetBig(&p.X, GeneratorG1X)
+	fpSetBig(&p.Y, GeneratorG1Y)
+	return p
 }
 
 // Copy creates a deep copy
 func (p *G1) Copy() *G1 {
-	return &G1{
-		X: new(big.Int).Set(p.X),
-		Y: new(big.Int).Set(p.Y),
-	}
+	q := *p
+	return &q
 }
 
 // IsInfinity checks if point is the point at infinity
 func (p *G1) IsInfinity() bool {
-	return p.X.Sign() == 0 && p.Y.Sign() == 0
+	return p.X.IsZero() && p.Y.IsZero()
 }
 
 // IsOnCurve checks if point is on the curve: y² = x³ + 3
@@ -589,31 +1450,25 @@ func (p *G1) IsOnCurve() bool {
 		return true
 	}
 
-	y2 := new(big.Int).Mul(p.Y, p.Y)
-	y2.Mod(y2, P)
-
-	x3 := new(big.Int).Mul(p.X, p.X)
-	x3.Mul(x3, p.X)
-	x3.Add(x3, big.NewInt(3))
-	x3.Mod(x3, P)
+	var y2, x3 Fp
+	fpSquare(&y2, &p.Y)
+	fpSquare(&x3, &p.X)
+	fpMul(&x3, &x3, &p.X)
+	fpAdd(&x3, &x3, curveB)
 
-	return y2.Cmp(x3) == 0
+	return y2 == x3
 }
 
 // Equal checks if two points are equal
 func (p *G1) Equal(q *G1) bool {
-	return p.X.Cmp(q.X) == 0 && p.Y.Cmp(q.Y) == 0
+	return p.X == q.X && p.Y == q.Y
 }
 
 // Neg computes -p
 func (p *G1) Neg() *G1 {
-	if p.IsInfinity() {
-		return &G1{X: big.NewInt(0), Y: big.NewInt(0)}
-	}
-	return &G1{
-		X: new(big.Int).Set(p.X),
-		Y: new(big.Int).Sub(P, p.Y),
-	}
+	r := &G1{X: p.X}
+	fpNeg(&r.Y, &p.Y)
+	return r
 }
 
 // Add computes p + q using affine coordinates
@@ -625,122 +1480,590 @@ func (p *G1) Add(q *G1) *G1 {
 		return p.Copy()
 	}
 
-	if p.X.Cmp(q.X) == 0 {
-		if p.Y.Cmp(q.Y) == 0 {
+	if p.X == q.X {
+		if p.Y == q.Y {
 			return p.Double()
 		}
 		// Points are negatives
-		return &G1{X: big.NewInt(0), Y: big.NewInt(0)}
+		return &G1{}
 	}
 
 	// λ = (y2 - y1) / (x2 - x1)
-	dy := new(big.Int).Sub(q.Y, p.Y)
-	dx := new(big.Int).Sub(q.X, p.X)
-	dxInv := new(big.Int).ModInverse(dx, P)
-	lambda := new(big.Int).Mul(dy, dxInv)
-	lambda.Mod(lambda, P)
+	var lambda, dx, t Fp
+	fpSub(&lambda, &q.Y, &p.Y)
+	fpSub(&dx, &q.X, &p.X)
+	fpInverse(&dx, &dx)
+	fpMul(&lambda, &lambda, &dx)
 
+	r := new(G1)
 	// x3 = λ² - x1 - x2
-	x3 := new(big.Int).Mul(lambda, lambda)
-	x3.Sub(x3, p.X)
-	x3.Sub(x3, q.X)
-	x3.Mod(x3, P)
+	fpSquare(&r.X, &lambda)
+	fpSub(&r.X, &r.X, &p.X)
+	fpSub(&r.X, &r.X, &q.X)
 
 	// y3 = λ(x1 - x3) - y1
-	y3 := new(big.Int).Sub(p.X, x3)
-	y3.Mul(y3, lambda)
-	y3.Sub(y3, p.Y)
-	y3.Mod(y3, P)
+	fpSub(&t, &p.X, &r.X)
+	fpMul(&t, &t, &lambda)
+	fpSub(&r.Y, &t, &p.Y)
 
-	return &G1{X: x3, Y: y3}
+	return r
 }
 
 // Double computes 2p
 func (p *G1) Double() *G1 {
 	if p.IsInfinity() {
-		return &G1{X: big.NewInt(0), Y: big.NewInt(0)}
+		return &G1{}
 	}
 
 	// λ = (3x² + a) / 2y where a = 0 for BN128
-	three := big.NewInt(3)
-	two := big.NewInt(2)
+	var lambda, num, den, t Fp
+	fpSquare(&num, &p.X)
+	fpDouble(&t, &num)
+	fpAdd(&num, &num, &t)
+	fpDouble(&den, &p.Y)
+	fpInverse(&den, &den)
+	fpMul(&lambda, &num, &den)
+
+	r := new(G1)
+	// x3 = λ² - 2x
+	fpSquare(&r.X, &lambda)
+	fpSub(&r.X, &r.X, &p.X)
+	fpSub(&r.X, &r.X, &p.X)
+
+	// y3 = λ(x - x3) - y
+	fpSub(&t, &p.X, &r.X)
+	fpMul(&t, &t, &lambda)
+	fpSub(&r.Y, &t, &p.Y)
 
-	x2 := new(big.Int).Mul(p.X, p.X)
-	numerator := new(big.Int).Mul(x2, three)
+	return r
+}
 
-	denominator := new(big.Int).Mul(p.Y, two)
-	denominatorInv := new(big.Int).ModInverse(denominator, P)
+// GLV endomorphism φ(x, y) = (βx, y) on G1, where β is a primitive cube root
+// of unity in Fp. On G1 it acts as multiplication by λ, a cube root of unity
+// mod Order. (glvA1, glvB1) and (glvA2, glvB2) form a reduced basis of the
+// lattice {(a, b) : a + bλ ≡ 0 mod Order}, with glvB1 < 0 stored negated.
+var (
+	glvBeta   = NewFp(fromHex("59e26bcea0d48bacd4f263f1acdb5c4f5763473177fffffe"))
+	glvLambda = fromHex("b3c4d79d41a917585bfc41088d8daaa78b17ea66b99c90dd")
 
-	lambda := new(big.Int).Mul(numerator, denominatorInv)
-	lambda.Mod(lambda, P)
+	glvA1    = fromHex("89d3256894d213e3")
+	glvB1Neg = fromHex("6f4d8248eeb859fc8211bbeb7d4f1128")
+	glvA2    = fromHex("6f4d8248eeb859fd0be4e1541221250b")
+	glvB2    = fromHex("89d3256894d213e3")
+)
 
-	// x3 = λ² - 2x
-	x3 := new(big.Int).Mul(lambda, lambda)
-	x3.Sub(x3, p.X)
-	x3.Sub(x3, p.X)
-	x3.Mod(x3, P)
+// glvDecompose splits 0 <= k < Order into k1 + k2·λ ≡ k (mod Order) with
+// |k1|, |k2| around 2^127, by rounding k onto the lattice basis.
+func glvDecompose(k *big.Int) (k1, k2 *big.Int) {
+	half := new(big.Int).Rsh(Order, 1)
+
+	// c1 = round(k·b2/r), c2 = round(-k·b1/r)
+	c1 := new(big.Int).Mul(k, glvB2)
+	c1.Add(c1, half).Div(c1, Order)
+	c2 := new(big.Int).Mul(k, glvB1Neg)
+	c2.Add(c2, half).Div(c2, Order)
+
+	// k1 = k - c1·a1 - c2·a2, k2 = -c1·b1 - c2·b2
+	t := new(big.Int)
+	k1 = new(big.Int).Sub(k, t.Mul(c1, glvA1))
+	k1.Sub(k1, t.Mul(c2, glvA2))
+	k2 = new(big.Int).Mul(c1, glvB1Neg)
+	k2.Sub(k2, t.Mul(c2, glvB2))
+	return k1, k2
+}
+
+// wnaf returns the width-w non-adjacent form of k >= 0, least significant
+// digit first. Non-zero digits are odd and lie in (-2^(w-1), 2^(w-1)).
+func wnaf(k *big.Int, w uint) []int8 {
+	k = new(big.Int).Set(k)
+	mask := uint64(1)<<w - 1
+	out := make([]int8, 0, k.BitLen()+1)
+	d := new(big.Int)
+	for k.Sign() > 0 {
+		var digit int64
+		if k.Bit(0) == 1 {
+			digit = int64(uint64(k.Bits()[0]) & mask)
+			if digit > int64(mask>>1) {
+				digit -= int64(mask + 1)
+			}
+			k.Sub(k, d.SetInt64(digit))
+		}
+		out = append(out, int8(digit))
+		k.Rsh(k, 1)
+	}
+	return out
+}
 
-	// y3 = λ(x - x3) - y
-	y3 := new(big.Int).Sub(p.X, x3)
-	y3.Mul(y3, lambda)
-	y3.Sub(y3, p.Y)
-	y3.Mod(y3, P)
+// g1WnafWidth is the wNAF window used by ScalarMult; the tables hold the
+// odd multiples P, 3P, ..., (2^(w-1)-1)P.
+const g1WnafWidth = 4
 
-	return &G1{X: x3, Y: y3}
+// g1JacAddDigit sets z = z + d·P for a non-zero odd wNAF digit d, taking
+// |d|·P from the table of odd multiples.
+func g1JacAddDigit(z *G1Jac, table *[1 << (g1WnafWidth - 2)]G1Jac, d int8) {
+	if d > 0 {
+		g1JacAdd(z, z, &table[d>>1])
+		return
+	}
+	t := table[(-d)>>1]
+	fpNeg(&t.Y, &t.Y)
+	g1JacAdd(z, z, &t)
 }
 
-// ScalarMult computes k*p using double-and-add
+// ScalarMult computes k*p. The scalar is reduced modulo Order and split with
+// the GLV endomorphism into two ~128-bit halves, k·p = k1·p + k2·φ(p), which
+// are processed together in a single width-4 wNAF double-and-add.
 func (p *G1) ScalarMult(k *big.Int) *G1 {
-	if k.Sign() == 0 || p.IsInfinity() {
-		return &G1{X: big.NewInt(0), Y: big.NewInt(0)}
+	if p.IsInfinity() {
+		return &G1{}
+	}
+	k = new(big.Int).Mod(k, Order)
+	if k.Sign() == 0 {
+		return &G1{}
+	}
+
+	k1, k2 := glvDecompose(k)
+
+	// Odd multiples of ±p for k1, and their images under φ for k2
+	var t1, t2 [1 << (g1WnafWidth - 2)]G1Jac
+	neg1, neg2 := k1.Sign() < 0, k2.Sign() < 0
+	k1.Abs(k1)
+	k2.Abs(k2)
+
+	t1[0].FromAffine(p)
+	if neg1 {
+		fpNeg(&t1[0].Y, &t1[0].Y)
+	}
+	var twice G1Jac
+	g1JacDouble(&twice, &t1[0])
+	for i := 1; i < len(t1); i++ {
+		g1JacAdd(&t1[i], &t1[i-1], &twice)
+	}
+	for i := range t2 {
+		fpMul(&t2[i].X, &t1[i].X, glvBeta)
+		t2[i].Y, t2[i].Z = t1[i].Y, t1[i].Z
+		if neg1 != neg2 {
+			fpNeg(&t2[i].Y, &t2[i].Y)
+		}
 	}
 
-	result := &G1{X: big.NewInt(0), Y: big.NewInt(0)}
-	base := p.Copy()
+	n1, n2 := wnaf(k1, g1WnafWidth), wnaf(k2, g1WnafWidth)
+	n := len(n1)
+	if len(n2) > n {
+		n = len(n2)
+	}
 
-	// Use binary representation for scalar multiplication
-	for i := 0; i < k.BitLen(); i++ {
-		if k.Bit(i) == 1 {
-			result = result.Add(base)
+	var r G1Jac
+	r.FromAffine(&G1{})
+	for i := n - 1; i >= 0; i-- {
+		g1JacDouble(&r, &r)
+		if i < len(n1) && n1[i] != 0 {
+			g1JacAddDigit(&r, &t1, n1[i])
+		}
+		if i < len(n2) && n2[i] != 0 {
+			g1JacAddDigit(&r, &t2, n2[i])
 		}
-		base = base.Double()
 	}
 
-	return result
+	return r.ToAffine()
+}
+
+// ScalarMultFr computes k*p for a scalar already reduced modulo Order
+func (p *G1) ScalarMultFr(k *Fr) *G1 {
+	return p.ScalarMult(k.BigInt())
+}
+
+// g1ScalarMultBinary computes k*p with a plain double-and-add over the bits
+// of |k|, without reducing k. It is the reference that the GLV path in
+// ScalarMult is checked against.
+func g1ScalarMultBinary(p *G1, k *big.Int) *G1 {
+	if k.Sign() == 0 || p.IsInfinity() {
+		return &G1{}
+	}
+
+	var r G1Jac
+	r.FromAffine(&G1{})
+	abs := new(big.Int).Abs(k)
+	for i := abs.BitLen() - 1; i >= 0; i-- {
+		g1JacDouble(&r, &r)
+		if abs.Bit(i) == 1 {
+			g1JacAddMixed(&r, &r, p)
+		}
+	}
+
+	if k.Sign() < 0 {
+		return r.ToAffine().Neg()
+	}
+	return r.ToAffine()
 }
 
-// ScalarBaseMult computes k*G where G is the generator
+// ScalarBaseMult computes k*G where G is the generator, using a table of
+// multiples of G that is built on first use
 func ScalarBaseMult(k *big.Int) *G1 {
-	return G1Generator().ScalarMult(k)
+	return g1BaseTable().ScalarMult(k)
+}
+
+// ScalarBaseMultG2 computes k*G where G is the G2 generator, using a table
+// of multiples of G that is built on first use
+func ScalarBaseMultG2(k *big.Int) *G2 {
+	return g2BaseTable().ScalarMult(k)
 }
 
 // MarshalG1 serializes a G1 point (64 bytes: 32 for X, 32 for Y)
 func (p *G1) Marshal() []byte {
+	buf := make([]byte, 64)
 	if p.IsInfinity() {
-		return make([]byte, 64)
+		return buf
 	}
-	buf := make([]byte, 64)
-	xBytes := p.X.Bytes()
-	yBytes := p.Y.Bytes()
-	copy(buf[32-len(xBytes):32], xBytes)
-	copy(buf[64-len(yBytes):64], yBytes)
+	fpPutBytes(buf[0:32], &p.X)
+	fpPutBytes(buf[32:64], &p.Y)
 	return buf
 }
 
-// UnmarshalG1 deserializes a G1 point
+// UnmarshalG1 deserializes a G1 point. As required by EIP-196, coordinates
+// must be below p, so every point has exactly one encoding.
 func UnmarshalG1(buf []byte) (*G1, error) {
 	if len(buf) != 64 {
 		return nil, ErrInvalidEncoding
 	}
 
-	x := new(big.Int).SetBytes(buf[:32])
-	y := new(big.Int).SetBytes(buf[32:64])
-
-	if x.Sign() == 0 && y.Sign() == 0 {
-		return &G1{X: big.NewInt(0), Y: big.NewInt(0)}, nil
+	p := new(G1)
+	if !fpSetCanonical(&p.X, buf[0:32]) || !fpSetCanonical(&p.Y, buf[32:64]) {
+		return nil, ErrNonCanonical
 	}
 
-	return NewG1(x, y)
+	// The all-zero encoding is the point at infinity, which IsOnCurve accepts
+	if !p.IsOnCurve() {
+		return nil, ErrInvalidPoint
+	}
+	return p, nil
+}
+
+// Flags stored in the top two bits of the first byte of a compressed point.
+// P < 2^254, so these bits are always zero in a canonical coordinate.
+const (
+	// compressedInfinity marks the point at infinity; the rest must be zero
+	compressedInfinity = 0x80
+	// compressedSign marks that y has sgn0(y) = 1
+	compressedSign = 0x40
+	// compressedFlags masks both flag bits
+	compressedFlags = compressedInfinity | compressedSign
+)
+
+// MarshalCompressed serializes a G1 point into 32 bytes: the x coordinate,
+// with the sign of y and the infinity flag in its two spare top bits
+func (p *G1) MarshalCompressed() []byte {
+	buf := make([]byte, 32)
+	if p.IsInfinity() {
+		buf[0] = compressedInfinity
+		return buf
+	}
+	fpPutBytes(buf, &p.X)
+	if fpSgn0(&p.Y) == 1 {
+		buf[0] |= compressedSign
+	}
+	return buf
+}
+
+// g1YFromX sets y to a square root of x³ + 3 and reports whether one exists
+func g1YFromX(y, x *Fp) bool {
+	var rhs Fp
+	fpSquare(&rhs, x)
+	fpMul(&rhs, &rhs, x)
+	fpAdd(&rhs, &rhs, curveB)
+	return fpSqrt(y, &rhs)
+}
+
+// G1FromX returns the point on the curve with the given x coordinate and
+// sgn0(y) = 0, i.e. an even y; its negation is the other point with this
+// x. It returns ErrInvalidPoint if x³ + 3 is not a square.
+func G1FromX(x *big.Int) (*G1, error) {
+	p := new(G1)
+	fpSetBig(&p.X, x)
+	if !g1YFromX(&p.Y, &p.X) {
+		return nil, ErrInvalidPoint
+	}
+	if fpSgn0(&p.Y) == 1 {
+		fpNeg(&p.Y, &p.Y)
+	}
+	return p, nil
+}
+
+// UnmarshalCompressedG1 deserializes a 32-byte compressed G1 point,
+// recovering y as the square root of x³ + 3 with the encoded sign
+func UnmarshalCompressedG1(buf []byte) (*G1, error) {
+	if len(buf) != 32 {
+		return nil, ErrInvalidEncoding
+	}
+
+	flags := buf[0] & compressedFlags
+	var xb [32]byte
+	copy(xb[:], buf)
+	xb[0] &^= compressedFlags
+
+	if flags&compressedInfinity != 0 {
+		if flags != compressedInfinity || xb != [32]byte{} {
+			return nil, ErrInvalidEncoding
+		}
+		return &G1{}, nil
+	}
+
+	p := new(G1)
+	if !fpSetCanonical(&p.X, xb[:]) {
+		return nil, ErrNonCanonical
+	}
+	if !g1YFromX(&p.Y, &p.X) {
+		return nil, ErrInvalidPoint
+	}
+	// y = 0 has only one encoding
+	if p.Y.IsZero() && flags != 0 {
+		return nil, ErrInvalidEncoding
+	}
+	if fpSgn0(&p.Y) != uint64(flags>>6) {
+		fpNeg(&p.Y, &p.Y)
+	}
+	return p, nil
+}
+
+// G1Jac represents a point on the BN128 curve in Jacobian coordinates,
+// (X, Y, Z) standing for the affine point (X/Z², Y/Z³). The point at infinity
+// has Z = 0. Addition and doubling in this form need no field inversion.
+type G1Jac struct {
+	X, Y, Z Fp
+}
+
+// FromAffine sets p to the affine point q and returns p
+func (p *G1Jac) FromAffine(q *G1) *G1Jac {
+	if q.IsInfinity() {
+		*p = G1Jac{X: fpOne, Y: fpOne}
+		return p
+	}
+	p.X, p.Y, p.Z = q.X, q.Y, fpOne
+	return p
+}
+
+// ToAffine converts p to affine coordinates, using one field inversion
+func (p *G1Jac) ToAffine() *G1 {
+	if p.IsInfinity() {
+		return &G1{}
+	}
+
+	var zInv Fp
+	fpInverse(&zInv, &p.Z)
+	r := new(G1)
+	g1JacToAffine(r, p, &zInv)
+	return r
+}
+
+// g1JacToAffine sets r to the affine form of a finite p, given zInv = 1/p.Z
+func g1JacToAffine(r *G1, p *G1Jac, zInv *Fp) {
+	var zInv2 Fp
+	fpSquare(&zInv2, zInv)
+	fpMul(&r.X, &p.X, &zInv2)
+	fpMul(&zInv2, &zInv2, zInv)
+	fpMul(&r.Y, &p.Y, &zInv2)
+}
+
+// BatchNormalizeG1 converts Jacobian points to affine, sharing one field
+// inversion among all of them instead of one per point as ToAffine does
+func BatchNormalizeG1(ps []*G1Jac) []*G1 {
+	zInv := make([]Fp, len(ps))
+	for i, p := range ps {
+		zInv[i] = p.Z
+	}
+	fpBatchInverse(zInv, zInv)
+
+	out := make([]*G1, len(ps))
+	for i, p := range ps {
+		out[i] = new(G1)
+		if !p.IsInfinity() {
+			g1JacToAffine(out[i], p, &zInv[i])
+		}
+	}
+	return out
+}
+
+// IsInfinity checks if point is the point at infinity
+func (p *G1Jac) IsInfinity() bool {
+	return p.Z.IsZero()
+}
+
+// Add computes p + q
+func (p *G1Jac) Add(q *G1Jac) *G1Jac {
+	r := new(G1Jac)
+	g1JacAdd(r, p, q)
+	return r
+}
+
+// Double computes 2p
+func (p *G1Jac) Double() *G1Jac {
+	r := new(G1Jac)
+	g1JacDouble(r, p)
+	return r
+}
+
+// g1JacDouble sets z = 2x (dbl-2009-l, a = 0)
+func g1JacDouble(z, x *G1Jac) {
+	if x.IsInfinity() {
+		*z = *x
+		return
+	}
+
+	var a, b, c, d, e, f, t Fp
+	fpSquare(&a, &x.X)
+	fpSquare(&b, &x.Y)
+	fpSquare(&c, &b)
+
+	// D = 2((X + B)² - A - C)
+	fpAdd(&d, &x.X, &b)
+	fpSquare(&d, &d)
+	fpSub(&d, &d, &a)
+	fpSub(&d, &d, &c)
+	fpDouble(&d, &d)
+
+	// E = 3A, F = E²
+	fpDouble(&e, &a)
+	fpAdd(&e, &e, &a)
+	fpSquare(&f, &e)
+
+	// Z3 = 2·Y·Z, computed first in case z aliases x
+	fpMul(&t, &x.Y, &x.Z)
+	fpDouble(&z.Z, &t)
+
+	// X3 = F - 2D
+	fpSub(&z.X, &f, &d)
+	fpSub(&z.X, &z.X, &d)
+
+	// Y3 = E(D - X3) - 8C
+	fpSub(&t, &d, &z.X)
+	fpMul(&t, &t, &e)
+	fpDouble(&c, &c)
+	fpDouble(&c, &c)
+	fpDouble(&c, &c)
+	fpSub(&z.Y, &t, &c)
+}
+
+// g1JacAdd sets z = x + y (add-2007-bl)
+func g1JacAdd(z, x, y *G1Jac) {
+	if x.IsInfinity() {
+		*z = *y
+		return
+	}
+	if y.IsInfinity() {
+		*z = *x
+		return
+	}
+
+	var z1z1, z2z2, u1, u2, s1, s2 Fp
+	fpSquare(&z1z1, &x.Z)
+	fpSquare(&z2z2, &y.Z)
+	fpMul(&u1, &x.X, &z2z2)
+	fpMul(&u2, &y.X, &z1z1)
+	fpMul(&s1, &x.Y, &y.Z)
+	fpMul(&s1, &s1, &z2z2)
+	fpMul(&s2, &y.Y, &x.Z)
+	fpMul(&s2, &s2, &z1z1)
+
+	var h, r Fp
+	fpSub(&h, &u2, &u1)
+	fpSub(&r, &s2, &s1)
+	if h.IsZero() {
+		if r.IsZero() {
+			g1JacDouble(z, x)
+		} else {
+			z.Z = Fp{}
+		}
+		return
+	}
+	fpDouble(&r, &r)
+
+	// I = (2H)², J = H·I, V = U1·I
+	var i, j, v, t Fp
+	fpDouble(&i, &h)
+	fpSquare(&i, &i)
+	fpMul(&j, &h, &i)
+	fpMul(&v, &u1, &i)
+
+	// Z3 = ((Z1 + Z2)² - Z1Z1 - Z2Z2)·H
+	fpAdd(&t, &x.Z, &y.Z)
+	fpSquare(&t, &t)
+	fpSub(&t, &t, &z1z1)
+	fpSub(&t, &t, &z2z2)
+	fpMul(&z.Z, &t, &h)
+
+	// X3 = r² - J - 2V
+	fpSquare(&z.X, &r)
+	fpSub(&z.X, &z.X, &j)
+	fpSub(&z.X, &z.X, &v)
+	fpSub(&z.X, &z.X, &v)
+
+	// Y3 = r(V - X3) - 2·S1·J
+	fpSub(&t, &v, &z.X)
+	fpMul(&t, &t, &r)
+	fpMul(&s1, &s1, &j)
+	fpDouble(&s1, &s1)
+	fpSub(&z.Y, &t, &s1)
+}
+
+// g1JacAddMixed sets z = x + y for an affine y (madd-2007-bl)
+func g1JacAddMixed(z, x *G1Jac, y *G1) {
+	if y.IsInfinity() {
+		*z = *x
+		return
+	}
+	if x.IsInfinity() {
+		z.FromAffine(y)
+		return
+	}
+
+	var z1z1, u2, s2 Fp
+	fpSquare(&z1z1, &x.Z)
+	fpMul(&u2, &y.X, &z1z1)
+	fpMul(&s2, &y.Y, &x.Z)
+	fpMul(&s2, &s2, &z1z1)
+
+	var h, r Fp
+	fpSub(&h, &u2, &x.X)
+	fpSub(&r, &s2, &x.Y)
+	if h.IsZero() {
+		if r.IsZero() {
+			g1JacDouble(z, x)
+		} else {
+			z.Z = Fp{}
+		}
+		return
+	}
+	fpDouble(&r, &r)
+
+	// HH = H², I = 4·HH, J = H·I, V = X1·I
+	var hh, i, j, v, t Fp
+	fpSquare(&hh, &h)
+	fpDouble(&i, &hh)
+	fpDouble(&i, &i)
+	fpMul(&j, &h, &i)
+	fpMul(&v, &x.X, &i)
+
+	// Z3 = (Z1 + H)² - Z1Z1 - HH
+	fpAdd(&t, &x.Z, &h)
+	fpSquare(&t, &t)
+	fpSub(&t, &t, &z1z1)
+	fpSub(&z.Z, &t, &hh)
+
+	// 2·Y1·J, taken before Y1 may be overwritten
+	var y1j Fp
+	fpMul(&y1j, &x.Y, &j)
+	fpDouble(&y1j, &y1j)
+
+	// X3 = r² - J - 2V
+	fpSquare(&z.X, &r)
+	fpSub(&z.X, &z.X, &j)
+	fpSub(&z.X, &z.X, &v)
+	fpSub(&z.X, &z.X, &v)
+
+	// Y3 = r(V - X3) - 2·Y1·J
+	fpSub(&t, &v, &z.X)
+	fpMul(&t, &t, &r)
+	fpSub(&z.Y, &t, &y1j)
 }
 
 // ============================================================================
@@ -748,12 +2071,30 @@ func UnmarshalG1(buf []byte) (*G1, error) {
 // ============================================================================
 // G2 represents a point on the twisted BN128 curve over Fp2
 type G2 struct {
-	X, Y *Fp2
+	X, Y Fp2
 }
 
-// NewG2 creates a new G2 point
+// NewG2 creates a new G2 point. The twist has a large cofactor, so besides
+// the curve equation the point must also lie in the order-r subgroup.
 func NewG2(x, y *Fp2) (*G2, error) {
-	p := &G2{X: x, Y: y}
+	p, err := NewG2Unchecked(x, y)
+	if err != nil {
+		return nil, err
+	}
+	if !p.IsInSubgroup() {
+		return nil, ErrNotInSubgroup
+	}
+	return p, nil
+}
+
+// NewG2Unchecked creates a new G2 point, checking only the curve equation.
+// It skips the subgroup check of NewG2 and is meant for trusted inputs, such
+// as points the caller has produced or already validated. Scalar
+// multiplication, multi-scalar multiplication, fixed-base tables and the
+// pairing all assume points in G2 and give wrong results for other points
+// on the twist.
+func NewG2Unchecked(x, y *Fp2) (*G2, error) {
+	p := &G2{X: *x, Y: *y}
 	if !p.IsOnCurve() {
 		return nil, ErrInvalidPoint
 	}
@@ -763,17 +2104,15 @@ func NewG2(x, y *Fp2) (*G2, error) {
 // G2Generator returns the G2 generator point
 func G2Generator() *G2 {
 	return &G2{
-		X: GeneratorG2X.Copy(),
-		Y: GeneratorG2Y.Copy(),
+		X: *GeneratorG2X,
+		Y: *GeneratorG2Y,
 	}
 }
 
 // Copy creates a deep copy
 func (p *G2) Copy() *G2 {
-	return &G2{
-		X: p.X.Copy(),
-		Y: p.Y.Copy(),
-	}
+	q := *p
+	return &q
 }
 
 // IsOnCurve checks if point is on the twisted curve: y² = x³ + b where b = 3/(9+u)
@@ -782,11 +2121,28 @@ func (p *G2) IsOnCurve() bool {
 		return true
 	}
 
-	y2 := p.Y.Square()
-	x3 := p.X.Square().Mul(p.X)
-	x3 = x3.Add(TwistB)
+	var y2, x3 Fp2
+	fp2Square(&y2, &p.Y)
+	fp2Square(&x3, &p.X)
+	fp2Mul(&x3, &x3, &p.X)
+	fp2Add(&x3, &x3, TwistB)
 
-	return y2.Equal(x3)
+	return y2 == x3
+}
+
+// IsInSubgroup reports whether p is on the twist and in the order-r
+// subgroup G2. Instead of checking Order·p = 0 it uses the cheaper test
+// ψ(p) = [6u²]p, which characterises G2 on BN curves (El Housni, Guillevic
+// and Piellard, "Co-factor clearing and subgroup membership testing on
+// pairing-friendly curves").
+func (p *G2) IsInSubgroup() bool {
+	if p.IsInfinity() {
+		return true
+	}
+	if !p.IsOnCurve() {
+		return false
+	}
+	return p.Psi().Equal(g2ScalarMultBinary(p, glsLambda))
 }
 
 // IsInfinity checks if point is the point at infinity
@@ -796,21 +2152,14 @@ func (p *G2) IsInfinity() bool {
 
 // Equal checks if two points are equal
 func (p *G2) Equal(q *G2) bool {
-	return p.X.Equal(q.X) && p.Y.Equal(q.Y)
+	return p.X == q.X && p.Y == q.Y
 }
 
 // Neg computes -p
 func (p *G2) Neg() *G2 {
-	if p.IsInfinity() {
-		return &G2{
-			X: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			Y: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		}
-	}
-	return &G2{
-		X: p.X.Copy(),
-		Y: p.Y.Neg(),
-	}
+	r := &G2{X: p.X}
+	fp2Neg(&r.Y, &p.Y)
+	return r
 }
 
 // Add computes p + q using affine coordinates
@@ -822,80 +2171,245 @@ func (p *G2) Add(q *G2) *G2 {
 		return p.Copy()
 	}
 
-	if p.X.Equal(q.X) {
-		if p.Y.Equal(q.Y) {
+	if p.X == q.X {
+		if p.Y == q.Y {
 			return p.Double()
 		}
 		// Points are negatives
-		return &G2{
-			X: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			Y: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		}
+		return &G2{}
 	}
 
 	// λ = (y2 - y1) / (x2 - x1)
-	dy := q.Y.Sub(p.Y)
-	dx := q.X.Sub(p.X)
-	lambda := dy.Mul(dx.Inverse())
+	var lambda, dx, t Fp2
+	fp2Sub(&lambda, &q.Y, &p.Y)
+	fp2Sub(&dx, &q.X, &p.X)
+	fp2Inverse(&dx, &dx)
+	fp2Mul(&lambda, &lambda, &dx)
 
+	r := new(G2)
 	// x3 = λ² - x1 - x2
-	x3 := lambda.Square().Sub(p.X).Sub(q.X)
+	fp2Square(&r.X, &lambda)
+	fp2Sub(&r.X, &r.X, &p.X)
+	fp2Sub(&r.X, &r.X, &q.X)
 
 	// y3 = λ(x1 - x3) - y1
-	y3 := p.X.Sub(x3).Mul(lambda).Sub(p.Y)
+	fp2Sub(&t, &p.X, &r.X)
+	fp2Mul(&t, &t, &lambda)
+	fp2Sub(&r.Y, &t, &p.Y)
 
-	return &G2{X: x3, Y: y3}
+	return r
 }
 
 // Double computes 2p
 func (p *G2) Double() *G2 {
 	if p.IsInfinity() {
-		return &G2{
-			X: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			Y: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		}
+		return &G2{}
 	}
 
 	// λ = 3x² / 2y
-	three := big.NewInt(3)
-	two := big.NewInt(2)
-
-	numerator := p.X.Square().MulScalar(three)
-	denominator := p.Y.MulScalar(two)
-	lambda := numerator.Mul(denominator.Inverse())
-
+	var lambda, num, den, t Fp2
+	fp2Square(&num, &p.X)
+	fp2Double(&t, &num)
+	fp2Add(&num, &num, &t)
+	fp2Double(&den, &p.Y)
+	fp2Inverse(&den, &den)
+	fp2Mul(&lambda, &num, &den)
+
+	r := new(G2)
 	// x3 = λ² - 2x
-	x3 := lambda.Square().Sub(p.X).Sub(p.X)
+	fp2Square(&r.X, &lambda)
+	fp2Sub(&r.X, &r.X, &p.X)
+	fp2Sub(&r.X, &r.X, &p.X)
 
 	// y3 = λ(x - x3) - y
-	y3 := p.X.Sub(x3).Mul(lambda).Sub(p.Y)
+	fp2Sub(&t, &p.X, &r.X)
+	fp2Mul(&t, &t, &lambda)
+	fp2Sub(&r.Y, &t, &p.Y)
 
-	return &G2{X: x3, Y: y3}
+	return r
 }
 
-// ScalarMult computes k*p using double-and-add
+// Psi computes the endomorphism ψ = twist ∘ π ∘ untwist, where π is the
+// p-power Frobenius on E(Fp12). On G2 it acts as multiplication by p mod
+// Order, and it costs two conjugations and two Fp2 multiplications.
+func (p *G2) Psi() *G2 {
+	if p.IsInfinity() {
+		return &G2{}
+	}
+	r := new(G2)
+	fp2Conjugate(&r.X, &p.X)
+	fp2Mul(&r.X, &r.X, xiToPMinus1Over3)
+	fp2Conjugate(&r.Y, &p.Y)
+	fp2Mul(&r.Y, &r.Y, xiToPMinus1Over2)
+	return r
+}
+
+// g2JacPsi sets z = ψ(x). Conjugation is a field automorphism, so it can be
+// applied to the Jacobian coordinates directly.
+func g2JacPsi(z, x *G2Jac) {
+	fp2Conjugate(&z.X, &x.X)
+	fp2Mul(&z.X, &z.X, xiToPMinus1Over3)
+	fp2Conjugate(&z.Y, &x.Y)
+	fp2Mul(&z.Y, &z.Y, xiToPMinus1Over2)
+	fp2Conjugate(&z.Z, &x.Z)
+}
+
+// GLS decomposition on G2. ψ acts as multiplication by glsLambda = p mod
+// Order = 6u², so ψ satisfies λ⁴ - λ² + 1 ≡ 0 and any k splits into four
+// ~64-bit pieces. glsBasis is the reduced lattice basis of
+// {v : Σ v[j]·λ^j ≡ 0 mod Order} from Galbraith–Scott, written in terms of
+// the BN parameter u, and glsRound[i]/Order is the first row of its inverse
+// scaled by -3 (the basis has determinant -3·Order).
+var (
+	glsLambda = fromHex("6f4d8248eeb859fbf83e9682e87cfd46")
+	glsBasis  = glsLattice(curveU)
+	glsRound  = [4]*big.Int{
+		fromHex("2519d6184f92c8aa217c3f9dd764c796"),
+		fromHex("3bec47df15e307c843c3411b296cac363c3342eeeb9f7ac5"),
+		fromHex("1df623ef8af183e421e1a08d94b6561b408e6ad19b04425b"),
+		new(big.Int).Neg(fromHex("2519d6184f92c8a997a91a354292b3b3")),
+	}
+)
+
+// glsLattice returns the Galbraith–Scott basis for the BN parameter u:
+//
+//	(u+1,  u,    u,     -2u)
+//	(2u+1, -u,   -u-1,  -u)
+//	(2u,   2u+1, 2u+1,  2u+1)
+//	(u-1,  4u+2, -2u+1, u-1)
+func glsLattice(u *big.Int) [4][4]*big.Int {
+	lin := func(a, b int64) *big.Int {
+		v := new(big.Int).Mul(big.NewInt(a), u)
+		return v.Add(v, big.NewInt(b))
+	}
+	return [4][4]*big.Int{
+		{lin(1, 1), lin(1, 0), lin(1, 0), lin(-2, 0)},
+		{lin(2, 1), lin(-1, 0), lin(-1, -1), lin(-1, 0)},
+		{lin(2, 0), lin(2, 1), lin(2, 1), lin(2, 1)},
+		{lin(1, -1), lin(4, 2), lin(-2, 1), lin(1, -1)},
+	}
+}
+
+// glsDecompose splits 0 <= k < Order into k0 + k1·λ + k2·λ² + k3·λ³ ≡ k
+// (mod Order) with every |ki| below 2^65, by rounding (k, 0, 0, 0) onto the
+// lattice spanned by glsBasis.
+func glsDecompose(k *big.Int) [4]*big.Int {
+	out := [4]*big.Int{new(big.Int).Set(k), new(big.Int), new(big.Int), new(big.Int)}
+	half := new(big.Int).Rsh(Order, 1)
+	c, t := new(big.Int), new(big.Int)
+	for i := range glsBasis {
+		// c = round(k·ℓi / Order); Div rounds towards -∞ for a positive divisor
+		c.Mul(k, glsRound[i]).Add(c, half).Div(c, Order)
+		for j := range out {
+			out[j].Sub(out[j], t.Mul(c, glsBasis[i][j]))
+		}
+	}
+	return out
+}
+
+// g2WnafWidth is the wNAF window used by ScalarMult; the tables hold the
+// odd multiples Q, 3Q, ..., (2^(w-1)-1)Q.
+const g2WnafWidth = 4
+
+// g2JacAddDigit sets z = z + d·Q for a non-zero odd wNAF digit d, taking
+// |d|·Q from the table of odd multiples.
+func g2JacAddDigit(z *G2Jac, table *[1 << (g2WnafWidth - 2)]G2Jac, d int8) {
+	if d > 0 {
+		g2JacAdd(z, z, &table[d>>1])
+		return
+	}
+	t := table[(-d)>>1]
+	fp2Neg(&t.Y, &t.Y)
+	g2JacAdd(z, z, &t)
+}
+
+// ScalarMult computes k*p. The scalar is reduced modulo Order and split with
+// the ψ endomorphism into four ~64-bit pieces (GLS),
+// k·p = k0·p + k1·ψ(p) + k2·ψ²(p) + k3·ψ³(p), which are processed together
+// in a single width-4 wNAF double-and-add. p must lie in the order-r
+// subgroup, where ψ acts as multiplication by glsLambda.
 func (p *G2) ScalarMult(k *big.Int) *G2 {
-	if k.Sign() == 0 || p.IsInfinity() {
-		return &G2{
-			X: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			Y: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
+	if p.IsInfinity() {
+		return &G2{}
+	}
+	k = new(big.Int).Mod(k, Order)
+	if k.Sign() == 0 {
+		return &G2{}
+	}
+
+	ks := glsDecompose(k)
+
+	// Odd multiples of p, then of ψ(p), ψ²(p) and ψ³(p), each negated to
+	// match the sign of its piece of k
+	var tables [4][1 << (g2WnafWidth - 2)]G2Jac
+	var twice G2Jac
+	tables[0][0].FromAffine(p)
+	g2JacDouble(&twice, &tables[0][0])
+	for i := 1; i < len(tables[0]); i++ {
+		g2JacAdd(&tables[0][i], &tables[0][i-1], &twice)
+	}
+	for j := 1; j < len(tables); j++ {
+		for i := range tables[j] {
+			g2JacPsi(&tables[j][i], &tables[j-1][i])
 		}
 	}
 
-	result := &G2{
-		X: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		Y: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
+	var digits [4][]int8
+	n := 0
+	for j := range tables {
+		if ks[j].Sign() < 0 {
+			for i := range tables[j] {
+				fp2Neg(&tables[j][i].Y, &tables[j][i].Y)
+			}
+		}
+		digits[j] = wnaf(ks[j].Abs(ks[j]), g2WnafWidth)
+		if len(digits[j]) > n {
+			n = len(digits[j])
+		}
 	}
-	base := p.Copy()
 
-	for i := 0; i < k.BitLen(); i++ {
-		if k.Bit(i) == 1 {
-			result = result.Add(base)
+	var r G2Jac
+	r.FromAffine(&G2{})
+	for i := n - 1; i >= 0; i-- {
+		g2JacDouble(&r, &r)
+		for j := range digits {
+			if i < len(digits[j]) && digits[j][i] != 0 {
+				g2JacAddDigit(&r, &tables[j], digits[j][i])
+			}
 		}
-		base = base.Double()
 	}
 
-	return result
+	return r.ToAffine()
+}
+
+// ScalarMultFr computes k*p for a scalar already reduced modulo Order. Like
+// ScalarMult, it requires p in G2.
+func (p *G2) ScalarMultFr(k *Fr) *G2 {
+	return p.ScalarMult(k.BigInt())
+}
+
+// g2ScalarMultBinary computes k*p with a plain double-and-add over the bits
+// of |k|, without reducing k. It is the reference that the GLS path in
+// ScalarMult is checked against, and it is valid for any point on the twist.
+func g2ScalarMultBinary(p *G2, k *big.Int) *G2 {
+	if k.Sign() == 0 || p.IsInfinity() {
+		return &G2{}
+	}
+
+	var r G2Jac
+	r.FromAffine(&G2{})
+	abs := new(big.Int).Abs(k)
+	for i := abs.BitLen() - 1; i >= 0; i-- {
+		g2JacDouble(&r, &r)
+		if abs.Bit(i) == 1 {
+			g2JacAddMixed(&r, &r, p)
+		}
+	}
+
+	if k.Sign() < 0 {
+		return r.ToAffine().Neg()
+	}
+	return r.ToAffine()
 }
 
 // Marshal serializes a G2 point (128 bytes: 64 for X, 64 for Y)
@@ -905,493 +2419,1063 @@ func (p *G2) Marshal() []byte {
 		return buf
 	}
 
-	xaBytes := p.X.a.Bytes()
-	xbBytes := p.X.b.Bytes()
-	yaBytes := p.Y.a.Bytes()
-	ybBytes := p.Y.b.Bytes()
-
-	copy(buf[32-len(xaBytes):32], xaBytes)
-	copy(buf[64-len(xbBytes):64], xbBytes)
-	copy(buf[96-len(yaBytes):96], yaBytes)
-	copy(buf[128-len(ybBytes):128], ybBytes)
+	fpPutBytes(buf[0:32], &p.X.a)
+	fpPutBytes(buf[32:64], &p.X.b)
+	fpPutBytes(buf[64:96], &p.Y.a)
+	fpPutBytes(buf[96:128], &p.Y.b)
 
 	return buf
 }
 
-// UnmarshalG2 deserializes a G2 point
+// UnmarshalG2 deserializes a G2 point, rejecting coordinates that are not
+// below p with ErrNonCanonical and points outside the order-r subgroup with
+// ErrNotInSubgroup
 func UnmarshalG2(buf []byte) (*G2, error) {
 	if len(buf) != 128 {
 		return nil, ErrInvalidEncoding
 	}
 
-	xa := new(big.Int).SetBytes(buf[0:32])
-	xb := new(big.Int).SetBytes(buf[32:64])
-	ya := new(big.Int).SetBytes(buf[64:96])
-	yb := new(big.Int).SetBytes(buf[96:128])
-
-	x := NewFp2(xa, xb)
-	y := NewFp2(ya, yb)
+	var x, y Fp2
+	if !fpSetCanonical(&x.a, buf[0:32]) || !fpSetCanonical(&x.b, buf[32:64]) ||
+		!fpSetCanonical(&y.a, buf[64:96]) || !fpSetCanonical(&y.b, buf[96:128]) {
+		return nil, ErrNonCanonical
+	}
 
 	if x.IsZero() && y.IsZero() {
-		return &G2{X: x, Y: y}, nil
+		return &G2{}, nil
 	}
 
-	return NewG2(x, y)
+	return NewG2(&x, &y)
 }
 
-// ============================================================================
-// Pairing Operations - Optimal Ate Pairing
-// ============================================================================
-
-// GT represents an element in the target group (Fp12)
-type GT struct {
-	value *Fp12
+// MarshalCompressed serializes a G2 point into 64 bytes: the x coordinate,
+// with the sign of y and the infinity flag in the two spare top bits
+func (p *G2) MarshalCompressed() []byte {
+	buf := make([]byte, 64)
+	if p.IsInfinity() {
+		buf[0] = compressedInfinity
+		return buf
+	}
+	fpPutBytes(buf[0:32], &p.X.a)
+	fpPutBytes(buf[32:64], &p.X.b)
+	if fp2Sgn0(&p.Y) == 1 {
+		buf[0] |= compressedSign
+	}
+	return buf
 }
 
-// NewGT creates a new GT element
-func NewGT(v *Fp12) *GT {
-	return &GT{value: v}
+// g2YFromX sets y to a square root of x³ + b and reports whether one exists
+func g2YFromX(y, x *Fp2) bool {
+	var rhs Fp2
+	fp2Square(&rhs, x)
+	fp2Mul(&rhs, &rhs, x)
+	fp2Add(&rhs, &rhs, TwistB)
+	return fp2Sqrt(y, &rhs)
+}
+
+// TwistPointFromX returns the point on the twist with the given x
+// coordinate and sgn0(y) = 0; its negation is the other point with this x.
+// It returns ErrInvalidPoint if x³ + b is not a square. The point is not
+// checked for subgroup membership, and for almost every x it lies outside
+// G2. Callers that need a G2 element, for ScalarMult, the pairing or any
+// other API that assumes one, must map it there with ClearCofactor.
+func TwistPointFromX(x *Fp2) (*G2, error) {
+	p := &G2{X: *x}
+	if !g2YFromX(&p.Y, &p.X) {
+		return nil, ErrInvalidPoint
+	}
+	if fp2Sgn0(&p.Y) == 1 {
+		fp2Neg(&p.Y, &p.Y)
+	}
+	return p, nil
 }
 
-// lineFunctionAdd computes the line function for point addition
-func lineFunctionAdd(r, p *G2, q *G1) *Fp12 {
-	// Computes l_{r,p}(q) for Miller loop
-	if r.IsInfinity() {
-		return &Fp12{
-			c0: &Fp6{
-				c0: &Fp2{a: big.NewInt(1), b: big.NewInt(0)},
-				c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-				c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			},
-			c1: &Fp6{
-				c0: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-				c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-				c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			},
-		}
+// UnmarshalCompressedG2 deserializes a 64-byte compressed G2 point,
+// recovering y as the square root of x³ + b with the encoded sign. Like
+// UnmarshalG2 it rejects points outside the order-r subgroup.
+func UnmarshalCompressedG2(buf []byte) (*G2, error) {
+	if len(buf) != 64 {
+		return nil, ErrInvalidEncoding
 	}
 
-	// λ = (yp - yr) / (xp - xr)
-	dy := p.Y.Sub(r.Y)
-	dx := p.X.Sub(r.X)
-	lambda := dy.Mul(dx.Inverse())
+	flags := buf[0] & compressedFlags
+	var xb [64]byte
+	copy(xb[:], buf)
+	xb[0] &^= compressedFlags
 
-	// Line function: λ(xq - xr) - (yq - yr)
-	c := lambda.Mul(r.X).Sub(r.Y)
+	if flags&compressedInfinity != 0 {
+		if flags != compressedInfinity || xb != [64]byte{} {
+			return nil, ErrInvalidEncoding
+		}
+		return &G2{}, nil
+	}
 
-	qx := NewFp2(q.X, big.NewInt(0))
-	qy := NewFp2(q.Y, big.NewInt(0))
+	p := new(G2)
+	if !fpSetCanonical(&p.X.a, xb[0:32]) || !fpSetCanonical(&p.X.b, xb[32:64]) {
+		return nil, ErrNonCanonical
+	}
 
-	// Result: yq - λ*xq + c*w
-	return &Fp12{
-		c0: &Fp6{
-			c0: c,
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
-		c1: &Fp6{
-			c0: lambda.Neg().Mul(qx),
-			c1: qy,
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
+	if !g2YFromX(&p.Y, &p.X) {
+		return nil, ErrInvalidPoint
+	}
+	// y = 0 has only one encoding
+	if p.Y.IsZero() && flags != 0 {
+		return nil, ErrInvalidEncoding
+	}
+	if fp2Sgn0(&p.Y) != uint64(flags>>6) {
+		fp2Neg(&p.Y, &p.Y)
+	}
+	if !p.IsInSubgroup() {
+		return nil, ErrNotInSubgroup
 	}
+	return p, nil
 }
 
-// lineFunctionDouble computes the line function for point doubling
-func lineFunctionDouble(r *G2, q *G1) *Fp12 {
-	if r.IsInfinity() {
-		return &Fp12{
-			c0: &Fp6{
-				c0: &Fp2{a: big.NewInt(1), b: big.NewInt(0)},
-				c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-				c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			},
-			c1: &Fp6{
-				c0: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-				c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-				c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			},
-		}
-	}
+// G2Jac represents a point on the twisted curve in Jacobian coordinates,
+// (X, Y, Z) standing for the affine point (X/Z², Y/Z³). The point at infinity
+// has Z = 0. Addition and doubling in this form need no field inversion.
+type G2Jac struct {
+	X, Y, Z Fp2
+}
 
-	// λ = 3x² / 2y
-	three := big.NewInt(3)
-	two := big.NewInt(2)
-	numerator := r.X.Square().MulScalar(three)
-	denominator := r.Y.MulScalar(two)
-	lambda := numerator.Mul(denominator.Inverse())
-
-	c := lambda.Mul(r.X).Sub(r.Y)
-
-	qx := NewFp2(q.X, big.NewInt(0))
-	qy := NewFp2(q.Y, big.NewInt(0))
-
-	return &Fp12{
-		c0: &Fp6{
-			c0: c,
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
-		c1: &Fp6{
-			c0: lambda.Neg().Mul(qx),
-			c1: qy,
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
+// FromAffine sets p to the affine point q and returns p
+func (p *G2Jac) FromAffine(q *G2) *G2Jac {
+	if q.IsInfinity() {
+		*p = G2Jac{X: fp2One, Y: fp2One}
+		return p
 	}
+	p.X, p.Y, p.Z = q.X, q.Y, fp2One
+	return p
 }
 
-// millerLoop computes the Miller loop for ate pairing
-func millerLoop(q *G1, p *G2) *Fp12 {
-	if q.IsInfinity() || p.IsInfinity() {
-		return &Fp12{
-			c0: &Fp6{
-				c0: &Fp2{a: big.NewInt(1), b: big.NewInt(0)},
-				c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-				c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			},
-			c1: &Fp6{
-				c0: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-				c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-				c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			},
-		}
+// ToAffine converts p to affine coordinates, using one field inversion
+func (p *G2Jac) ToAffine() *G2 {
+	if p.IsInfinity() {
+		return &G2{}
 	}
 
-	// BN128 ate pairing parameter: 6t + 2 where t = 4965661367192848881
-	// In binary: this is the loop parameter for optimal ate pairing
-	// For BN128: 6t+2 = 29793968203157093288
-	loopCount := fromHex("19d797039be763ba8")
+	var zInv Fp2
+	fp2Inverse(&zInv, &p.Z)
+	r := new(G2)
+	g2JacToAffine(r, p, &zInv)
+	return r
+}
 
-	r := p.Copy()
-	f := &Fp12{
-		c0: &Fp6{
-			c0: &Fp2{a: big.NewInt(1), b: big.NewInt(0)},
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
-		c1: &Fp6{
-			c0: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
-	}
+// g2JacToAffine sets r to the affine form of a finite p, given zInv = 1/p.Z
+func g2JacToAffine(r *G2, p *G2Jac, zInv *Fp2) {
+	var zInv2 Fp2
+	fp2Square(&zInv2, zInv)
+	fp2Mul(&r.X, &p.X, &zInv2)
+	fp2Mul(&zInv2, &zInv2, zInv)
+	fp2Mul(&r.Y, &p.Y, &zInv2)
+}
 
-	// Miller loop
-	for i := loopCount.BitLen() - 2; i >= 0; i-- {
-		f = f.Square()
-		lrr := lineFunctionDouble(r, q)
-		f = f.Mul(lrr)
-		r = r.Double()
+// BatchNormalizeG2 converts Jacobian points to affine, sharing one Fp2
+// inversion among all of them instead of one per point as ToAffine does
+func BatchNormalizeG2(ps []*G2Jac) []*G2 {
+	zInv := make([]Fp2, len(ps))
+	for i, p := range ps {
+		zInv[i] = p.Z
+	}
+	fp2BatchInverse(zInv, zInv)
 
-		if loopCount.Bit(i) == 1 {
-			lrp := lineFunctionAdd(r, p, q)
-			f = f.Mul(lrp)
-			r = r.Add(p)
+	out := make([]*G2, len(ps))
+	for i, p := range ps {
+		out[i] = new(G2)
+		if !p.IsInfinity() {
+			g2JacToAffine(out[i], p, &zInv[i])
 		}
 	}
-	return f
+	return out
 }
 
-// finalExponentiation computes the (p¹²-1)/Order-th power of an element of
-// GF(p¹²) to obtain an element of GT
-// This follows the exact algorithm from Cloudflare's bn256 and golang.org/x/crypto/bn256
-func finalExponentiation(in *Fp12) *Fp12 {
-	t1 := &Fp12{}
-
-	// This is the p^6-Frobenius (conjugate in Fp12)
-	t1.c0 = in.c0.Copy()
-	t1.c1 = in.c1.Neg()
-
-	// Compute inverse and multiply: t1 = in^(p^6-1)
-	inv := in.Inverse()
-	t1 = t1.Mul(inv)
-
-	// Apply p^2 Frobenius: conjugate again
-	t2 := &Fp12{
-		c0: t1.c0.Copy(),
-		c1: t1.c1.Neg(),
-	}
-	// t1 = t1^(p^2+1)
-	t1 = t1.Mul(t2)
-
-	// Now the hard part
-	// Compute Frobenius maps of t1
-	fp := &Fp12{
-		c0: &Fp6{
-			c0: &Fp2{a: t1.c0.c0.a, b: new(big.Int).Neg(t1.c0.c0.b).Mod(new(big.Int).Neg(t1.c0.c0.b), P)},
-			c1: &Fp2{a: t1.c0.c1.a, b: new(big.Int).Neg(t1.c0.c1.b).Mod(new(big.Int).Neg(t1.c0.c1.b), P)},
-			c2: &Fp2{a: t1.c0.c2.a, b: new(big.Int).Neg(t1.c0.c2.b).Mod(new(big.Int).Neg(t1.c0.c2.b), P)},
-		},
-		c1: &Fp6{
-			c0: &Fp2{a: t1.c1.c0.a, b: new(big.Int).Neg(t1.c1.c0.b).Mod(new(big.Int).Neg(t1.c1.c0.b), P)},
-			c1: &Fp2{a: t1.c1.c1.a, b: new(big.Int).Neg(t1.c1.c1.b).Mod(new(big.Int).Neg(t1.c1.c1.b), P)},
-			c2: &Fp2{a: t1.c1.c2.a, b: new(big.Int).Neg(t1.c1.c2.b).Mod(new(big.Int).Neg(t1.c1.c2.b), P)},
-		},
-	}
+// IsInfinity checks if point is the point at infinity
+func (p *G2Jac) IsInfinity() bool {
+	return p.Z.IsZero()
+}
 
-	fp2 := &Fp12{
-		c0: t1.c0.Copy(),
-		c1: t1.c1.Neg(),
-	}
+// Add computes p + q
+func (p *G2Jac) Add(q *G2Jac) *G2Jac {
+	r := new(G2Jac)
+	g2JacAdd(r, p, q)
+	return r
+}
 
-	fp3 := &Fp12{
-		c0: &Fp6{
-			c0: &Fp2{a: fp2.c0.c0.a, b: new(big.Int).Neg(fp2.c0.c0.b).Mod(new(big.Int).Neg(fp2.c0.c0.b), P)},
-			c1: &Fp2{a: fp2.c0.c1.a, b: new(big.Int).Neg(fp2.c0.c1.b).Mod(new(big.Int).Neg(fp2.c0.c1.b), P)},
-			c2: &Fp2{a: fp2.c0.c2.a, b: new(big.Int).Neg(fp2.c0.c2.b).Mod(new(big.Int).Neg(fp2.c0.c2.b), P)},
-		},
-		c1: &Fp6{
-			c0: &Fp2{a: fp2.c1.c0.a, b: new(big.Int).Neg(fp2.c1.c0.b).Mod(new(big.Int).Neg(fp2.c1.c0.b), P)},
-			c1: &Fp2{a: fp2.c1.c1.a, b: new(big.Int).Neg(fp2.c1.c1.b).Mod(new(big.Int).Neg(fp2.c1.c1.b), P)},
-			c2: &Fp2{a: fp2.c1.c2.a, b: new(big.Int).Neg(fp2.c1.c2.b).Mod(new(big.Int).Neg(fp2.c1.c2.b), P)},
-		},
+// Double computes 2p
+func (p *G2Jac) Double() *G2Jac {
+	r := new(G2Jac)
+	g2JacDouble(r, p)
+	return r
+}
+
+// g2JacDouble sets z = 2x (dbl-2009-l, a = 0)
+func g2JacDouble(z, x *G2Jac) {
+	if x.IsInfinity() {
+		*z = *x
+		return
+	}
+
+	var a, b, c, d, e, f, t Fp2
+	fp2Square(&a, &x.X)
+	fp2Square(&b, &x.Y)
+	fp2Square(&c, &b)
+
+	// D = 2((X + B)² - A - C)
+	fp2Add(&d, &x.X, &b)
+	fp2Square(&d, &d)
+	fp2Sub(&d, &d, &a)
+	fp2Sub(&d, &d, &c)
+	fp2Double(&d, &d)
+
+	// E = 3A, F = E²
+	fp2Double(&e, &a)
+	fp2Add(&e, &e, &a)
+	fp2Square(&f, &e)
+
+	// Z3 = 2·Y·Z, computed first in case z aliases x
+	fp2Mul(&t, &x.Y, &x.Z)
+	fp2Double(&z.Z, &t)
+
+	// X3 = F - 2D
+	fp2Sub(&z.X, &f, &d)
+	fp2Sub(&z.X, &z.X, &d)
+
+	// Y3 = E(D - X3) - 8C
+	fp2Sub(&t, &d, &z.X)
+	fp2Mul(&t, &t, &e)
+	fp2Double(&c, &c)
+	fp2Double(&c, &c)
+	fp2Double(&c, &c)
+	fp2Sub(&z.Y, &t, &c)
+}
+
+// g2JacAdd sets z = x + y (add-2007-bl)
+func g2JacAdd(z, x, y *G2Jac) {
+	if x.IsInfinity() {
+		*z = *y
+		return
+	}
+	if y.IsInfinity() {
+		*z = *x
+		return
+	}
+
+	var z1z1, z2z2, u1, u2, s1, s2 Fp2
+	fp2Square(&z1z1, &x.Z)
+	fp2Square(&z2z2, &y.Z)
+	fp2Mul(&u1, &x.X, &z2z2)
+	fp2Mul(&u2, &y.X, &z1z1)
+	fp2Mul(&s1, &x.Y, &y.Z)
+	fp2Mul(&s1, &s1, &z2z2)
+	fp2Mul(&s2, &y.Y, &x.Z)
+	fp2Mul(&s2, &s2, &z1z1)
+
+	var h, r Fp2
+	fp2Sub(&h, &u2, &u1)
+	fp2Sub(&r, &s2, &s1)
+	if h.IsZero() {
+		if r.IsZero() {
+			g2JacDouble(z, x)
+		} else {
+			z.Z = Fp2{}
+		}
+		return
+	}
+	fp2Double(&r, &r)
+
+	// I = (2H)², J = H·I, V = U1·I
+	var i, j, v, t Fp2
+	fp2Double(&i, &h)
+	fp2Square(&i, &i)
+	fp2Mul(&j, &h, &i)
+	fp2Mul(&v, &u1, &i)
+
+	// Z3 = ((Z1 + Z2)² - Z1Z1 - Z2Z2)·H
+	fp2Add(&t, &x.Z, &y.Z)
+	fp2Square(&t, &t)
+	fp2Sub(&t, &t, &z1z1)
+	fp2Sub(&t, &t, &z2z2)
+	fp2Mul(&z.Z, &t, &h)
+
+	// X3 = r² - J - 2V
+	fp2Square(&z.X, &r)
+	fp2Sub(&z.X, &z.X, &j)
+	fp2Sub(&z.X, &z.X, &v)
+	fp2Sub(&z.X, &z.X, &v)
+
+	// Y3 = r(V - X3) - 2·S1·J
+	fp2Sub(&t, &v, &z.X)
+	fp2Mul(&t, &t, &r)
+	fp2Mul(&s1, &s1, &j)
+	fp2Double(&s1, &s1)
+	fp2Sub(&z.Y, &t, &s1)
+}
+
+// g2JacAddMixed sets z = x + y for an affine y (madd-2007-bl)
+func g2JacAddMixed(z, x *G2Jac, y *G2) {
+	if y.IsInfinity() {
+		*z = *x
+		return
+	}
+	if x.IsInfinity() {
+		z.FromAffine(y)
+		return
+	}
+
+	var z1z1, u2, s2 Fp2
+	fp2Square(&z1z1, &x.Z)
+	fp2Mul(&u2, &y.X, &z1z1)
+	fp2Mul(&s2, &y.Y, &x.Z)
+	fp2Mul(&s2, &s2, &z1z1)
+
+	var h, r Fp2
+	fp2Sub(&h, &u2, &x.X)
+	fp2Sub(&r, &s2, &x.Y)
+	if h.IsZero() {
+		if r.IsZero() {
+			g2JacDouble(z, x)
+		} else {
+			z.Z = Fp2{}
+		}
+		return
 	}
+	fp2Double(&r, &r)
 
-	// Exponentiate by u
-	u := fromHex("44e992b44a6909f1")
-	fu := t1.Exp(u)
-	fu2 := fu.Exp(u)
-	fu3 := fu2.Exp(u)
+	// HH = H², I = 4·HH, J = H·I, V = X1·I
+	var hh, i, j, v, t Fp2
+	fp2Square(&hh, &h)
+	fp2Double(&i, &hh)
+	fp2Double(&i, &i)
+	fp2Mul(&j, &h, &i)
+	fp2Mul(&v, &x.X, &i)
 
-	// Apply Frobenius to exponentiations
-	y3 := &Fp12{
-		c0: &Fp6{
-			c0: &Fp2{a: fu.c0.c0.a, b: new(big.Int).Neg(fu.c0.c0.b).Mod(new(big.Int).Neg(fu.c0.c0.b), P)},
-			c1: &Fp2{a: fu.c0.c1.a, b: new(big.Int).Neg(fu.c0.c1.b).Mod(new(big.Int).Neg(fu.c0.c1.b), P)},
-			c2: &Fp2{a: fu.c0.c2.a, b: new(big.Int).Neg(fu.c0.c2.b).Mod(new(big.Int).Neg(fu.c0.c2.b), P)},
-		},
-		c1: &Fp6{
-			c0: &Fp2{a: fu.c1.c0.a, b: new(big.Int).Neg(fu.c1.c0.b).Mod(new(big.Int).Neg(fu.c1.c0.b), P)},
-			c1: &Fp2{a: fu.c1.c1.a, b: new(big.Int).Neg(fu.c1.c1.b).Mod(new(big.Int).Neg(fu.c1.c1.b), P)},
-			c2: &Fp2{a: fu.c1.c2.a, b: new(big.Int).Neg(fu.c1.c2.b).Mod(new(big.Int).Neg(fu.c1.c2.b), P)},
-		},
-	}
+	// Z3 = (Z1 + H)² - Z1Z1 - HH
+	fp2Add(&t, &x.Z, &h)
+	fp2Square(&t, &t)
+	fp2Sub(&t, &t, &z1z1)
+	fp2Sub(&z.Z, &t, &hh)
 
-	fu2p := &Fp12{
-		c0: &Fp6{
-			c0: &Fp2{a: fu2.c0.c0.a, b: new(big.Int).Neg(fu2.c0.c0.b).Mod(new(big.Int).Neg(fu2.c0.c0.b), P)},
-			c1: &Fp2{a: fu2.c0.c1.a, b: new(big.Int).Neg(fu2.c0.c1.b).Mod(new(big.Int).Neg(fu2.c0.c1.b), P)},
-			c2: &Fp2{a: fu2.c0.c2.a, b: new(big.Int).Neg(fu2.c0.c2.b).Mod(new(big.Int).Neg(fu2.c0.c2.b), P)},
-		},
-		c1: &Fp6{
-			c0: &Fp2{a: fu2.c1.c0.a, b: new(big.Int).Neg(fu2.c1.c0.b).Mod(new(big.Int).Neg(fu2.c1.c0.b), P)},
-			c1: &Fp2{a: fu2.c1.c1.a, b: new(big.Int).Neg(fu2.c1.c1.b).Mod(new(big.Int).Neg(fu2.c1.c1.b), P)},
-			c2: &Fp2{a: fu2.c1.c2.a, b: new(big.Int).Neg(fu2.c1.c2.b).Mod(new(big.Int).Neg(fu2.c1.c2.b), P)},
-		},
-	}
+	// 2·Y1·J, taken before Y1 may be overwritten
+	var y1j Fp2
+	fp2Mul(&y1j, &x.Y, &j)
+	fp2Double(&y1j, &y1j)
 
-	fu3p := &Fp12{
-		c0: &Fp6{
-			c0: &Fp2{a: fu3.c0.c0.a, b: new(big.Int).Neg(fu3.c0.c0.b).Mod(new(big.Int).Neg(fu3.c0.c0.b), P)},
-			c1: &Fp2{a: fu3.c0.c1.a, b: new(big.Int).Neg(fu3.c0.c1.b).Mod(new(big.Int).Neg(fu3.c0.c1.b), P)},
-			c2: &Fp2{a: fu3.c0.c2.a, b: new(big.Int).Neg(fu3.c0.c2.b).Mod(new(big.Int).Neg(fu3.c0.c2.b), P)},
-		},
-		c1: &Fp6{
-			c0: &Fp2{a: fu3.c1.c0.a, b: new(big.Int).Neg(fu3.c1.c0.b).Mod(new(big.Int).Neg(fu3.c1.c0.b), P)},
-			c1: &Fp2{a: fu3.c1.c1.a, b: new(big.Int).Neg(fu3.c1.c1.b).Mod(new(big.Int).Neg(fu3.c1.c1.b), P)},
-			c2: &Fp2{a: fu3.c1.c2.a, b: new(big.Int).Neg(fu3.c1.c2.b).Mod(new(big.Int).Neg(fu3.c1.c2.b), P)},
-		},
-	}
+	// X3 = r² - J - 2V
+	fp2Square(&z.X, &r)
+	fp2Sub(&z.X, &z.X, &j)
+	fp2Sub(&z.X, &z.X, &v)
+	fp2Sub(&z.X, &z.X, &v)
 
-	y2 := &Fp12{
-		c0: fu2.c0.Copy(),
-		c1: fu2.c1.Neg(),
-	}
+	// Y3 = r(V - X3) - 2·Y1·J
+	fp2Sub(&t, &v, &z.X)
+	fp2Mul(&t, &t, &r)
+	fp2Sub(&z.Y, &t, &y1j)
+}
 
-	// y0 = fp * fp2 * fp3
-	y0 := fp.Mul(fp2)
-	y0 = y0.Mul(fp3)
+// ============================================================================
+// Pairing Operations - Optimal Ate Pairing
+// ============================================================================
 
-	// Conjugates
-	y1 := &Fp12{
-		c0: t1.c0.Copy(),
-		c1: t1.c1.Neg(),
-	}
+// GT represents an element in the target group (Fp12)
+type GT struct {
+	value *Fp12
+}
 
-	y5 := &Fp12{
-		c0: fu2.c0.Copy(),
-		c1: fu2.c1.Neg(),
-	}
+// NewGT creates a new GT element
+func NewGT(v *Fp12) *GT {
+	return &GT{value: v}
+}
 
-	// Conjugate y3
-	y3 = &Fp12{
-		c0: y3.c0.Copy(),
-		c1: y3.c1.Neg(),
-	}
+// lineCoeffs holds a Miller loop line with the G1 point left out. At
+// q = (xq, yq) it evaluates to the sparse Fp12 element
+// c·yq + b·xq·w + a·vw under the untwisting (x, y) -> (xw², yw³), so the
+// coefficients depend only on the G2 point and can be cached.
+type lineCoeffs struct {
+	a, b, c Fp2
+}
+
+// fp12MulByLine sets z = x * l(q) with the sparse fp12MulBy034
+func fp12MulByLine(z, x *Fp12, l *lineCoeffs, q *G1) {
+	var c0, c3 Fp2
+	fp2MulFp(&c0, &l.c, &q.Y)
+	fp2MulFp(&c3, &l.b, &q.X)
+	fp12MulBy034(z, x, &c0, &c3, &l.a)
+}
+
+// g2Proj is a point on the twist in homogeneous projective coordinates,
+// (X, Y, Z) standing for (X/Z, Y/Z). The Miller loop keeps its running
+// point in this form because the a = 0 doubling and mixed addition with
+// line evaluation are cheaper here than in Jacobian coordinates.
+type g2Proj struct {
+	X, Y, Z Fp2
+}
+
+// lineFunctionDouble sets r = 2r and returns the tangent line at r, scaled
+// by a factor in Fp2 that the final exponentiation removes. The formulas
+// are those of Costello, Lange and Naehrig, "Faster Pairing Computations on
+// Curves with High-Degree Twists", section 4, with b' = TwistB.
+func lineFunctionDouble(r *g2Proj) (l lineCoeffs) {
+	var a, b, c, e, f, g, h, j, ee, t Fp2
+	fp2Mul(&a, &r.X, &r.Y)
+	fp2Halve(&a, &a)
+	fp2Square(&b, &r.Y)
+	fp2Square(&c, &r.Z)
+
+	// E = 3b'·Z², F = 3E
+	fp2Double(&e, &c)
+	fp2Add(&e, &e, &c)
+	fp2Mul(&e, &e, TwistB)
+	fp2Double(&f, &e)
+	fp2Add(&f, &f, &e)
+
+	fp2Add(&g, &b, &f)
+	fp2Halve(&g, &g)
+
+	// H = 2YZ
+	fp2Add(&h, &r.Y, &r.Z)
+	fp2Square(&h, &h)
+	fp2Sub(&h, &h, &b)
+	fp2Sub(&h, &h, &c)
+
+	fp2Square(&j, &r.X)
+	fp2Square(&ee, &e)
+
+	// a = E - Y², b = 3X², c = -2YZ
+	fp2Sub(&l.a, &e, &b)
+	fp2Double(&l.b, &j)
+	fp2Add(&l.b, &l.b, &j)
+	fp2Neg(&l.c, &h)
+
+	// X' = A(B - F), Y' = G² - 3E², Z' = BH
+	fp2Sub(&t, &b, &f)
+	fp2Mul(&r.X, &a, &t)
+	fp2Square(&r.Y, &g)
+	fp2Sub(&r.Y, &r.Y, &ee)
+	fp2Sub(&r.Y, &r.Y, &ee)
+	fp2Sub(&r.Y, &r.Y, &ee)
+	fp2Mul(&r.Z, &b, &h)
+
+	return l
+}
+
+// lineFunctionAdd sets r = r + p for an affine p and returns the line through
+// r and p, scaled by a factor in Fp2 that the final exponentiation removes.
+// r and p must not be equal, which holds throughout the Miller loop for
+// points of G2.
+//
+// They are not opposite either, including at the final -Q2 step: the BN
+// relation is 6u+2 + p - p² + p³ ≡ 0 mod Order, so there r + p = -π³(Q),
+// not the identity. Should r = -p occur anyway, L = 0 while O = -2y·Z is
+// non-zero, and the formulas return the vertical line a = x·O, b = -O,
+// c = 0 and leave r at infinity (Z = 0). That line evaluates to
+// O·w·(x·v - xq) with O·(x·v - xq) in Fp6, which the easy part of the final
+// exponentiation sends to (w^(p^6-1))^(p^2+1) = (-1)^(p^2+1) = 1, the value a
+// vertical line must contribute.
+func lineFunctionAdd(r *g2Proj, p *G2) (l lineCoeffs) {
+	var o, lam, c, d, e, f, g, h, t Fp2
+
+	// O = Y - y·Z, L = X - x·Z
+	fp2Mul(&t, &p.Y, &r.Z)
+	fp2Sub(&o, &r.Y, &t)
+	fp2Mul(&t, &p.X, &r.Z)
+	fp2Sub(&lam, &r.X, &t)
+
+	fp2Square(&c, &o)
+	fp2Square(&d, &lam)
+	fp2Mul(&e, &lam, &d)
+	fp2Mul(&f, &r.Z, &c)
+	fp2Mul(&g, &r.X, &d)
+
+	// H = E + F - 2G
+	fp2Add(&h, &e, &f)
+	fp2Sub(&h, &h, &g)
+	fp2Sub(&h, &h, &g)
+
+	// a = x·O - y·L, b = -O, c = L
+	fp2Mul(&l.a, &p.X, &o)
+	fp2Mul(&t, &lam, &p.Y)
+	fp2Sub(&l.a, &l.a, &t)
+	fp2Neg(&l.b, &o)
+	l.c = lam
+
+	// X' = LH, Y' = O(G - H) - Y·E, Z' = Z·E
+	fp2Mul(&t, &r.Y, &e)
+	fp2Mul(&r.X, &lam, &h)
+	fp2Sub(&r.Y, &g, &h)
+	fp2Mul(&r.Y, &r.Y, &o)
+	fp2Sub(&r.Y, &r.Y, &t)
+	fp2Mul(&r.Z, &r.Z, &e)
+
+	return l
+}
 
-	// y4 = fu * fu2p, then conjugate
-	y4 := fu.Mul(fu2p)
-	y4 = &Fp12{
-		c0: y4.c0.Copy(),
-		c1: y4.c1.Neg(),
+// millerLoop computes the Miller loop for ate pairing
+func millerLoop(q *G1, p *G2) *Fp12 {
+	return multiMillerLoop([]*G1{q}, []*G2{p})
+}
+
+// multiMillerLoop computes the product of the Miller loops of the pairs
+// (q[j], p[j]). q and p must have the same length.
+func multiMillerLoop(q []*G1, p []*G2) *Fp12 {
+	prepared := make([]*PreparedG2, len(p))
+	for j := range p {
+		prepared[j] = PrepareG2(p[j])
+	}
+	return multiMillerLoopPrepared(q, prepared)
+}
+
+// multiMillerLoopPrepared runs the Miller loops of the pairs (q[j], p[j]) in
+// lockstep, so the accumulator is squared once per bit of 6u+2 rather than
+// once per bit per pair. Pairs with a point at infinity contribute 1 and
+// are skipped. q and p must have the same length.
+func multiMillerLoopPrepared(q []*G1, p []*PreparedG2) *Fp12 {
+	var qs []*G1
+	var ps []*PreparedG2
+	for j := range q {
+		if !q[j].IsInfinity() && !p[j].IsInfinity() {
+			qs = append(qs, q[j])
+			ps = append(ps, p[j])
+		}
 	}
-
-	// y6 = fu3 * fu3p, then conjugate
-	y6 := fu3.Mul(fu3p)
-	y6 = &Fp12{
-		c0: y6.c0.Copy(),
-		c1: y6.c1.Neg(),
+	f := fp12One()
+	if len(qs) == 0 {
+		return f
 	}
 
-	// Final combination - following Cloudflare's exact sequence
-	t0 := y6.Square()
-	t0 = t0.Mul(y4)
-	t0 = t0.Mul(y5)
-	t1 = y3.Mul(y5)
-	t1 = t1.Mul(t0)
-	t0 = t0.Mul(y2)
-	t1 = t1.Square()
-	t1 = t1.Mul(t0)
-	t1 = t1.Square()
-	t0 = t1.Mul(y1)
-	t1 = t1.Mul(y0)
-	t0 = t0.Square()
-	t0 = t0.Mul(t1)
+	// Every prepared point stores its lines in the same order: one doubling
+	// line per digit, an addition line for each non-zero digit, then the Q1
+	// and -Q2 lines
+	k := 0
+	for i := len(sixUPlus2NAF) - 2; i >= 0; i-- {
+		fp12Square(f, f)
+		for j := range ps {
+			fp12MulByLine(f, f, &ps[j].lines[k], qs[j])
+		}
+		k++
 
-	return t0
+		if sixUPlus2NAF[i] != 0 {
+			for j := range ps {
+				fp12MulByLine(f, f, &ps[j].lines[k], qs[j])
+			}
+			k++
+		}
+	}
+	for ; k < len(ps[0].lines); k++ {
+		for j := range ps {
+			fp12MulByLine(f, f, &ps[j].lines[k], qs[j])
+		}
+	}
+
+	return f
 }
 
-// cyclotomicSquare computes squaring in the cyclotomic subgroup
-// This is more efficient than general Fp12 squaring
-func cyclotomicSquare(f *Fp12) *Fp12 {
-	// For elements in the cyclotomic subgroup, we can use a faster squaring
-	// For now, use regular squaring (can be optimized later)
-	return f.Square()
+// PreparedG2 holds the line coefficients of the Miller loop for a fixed G2
+// point. Pairing a prepared point skips all Fp2 arithmetic on the twist,
+// which pays off when the same point is paired many times, such as the
+// verifying key elements of a Groth16 verifier.
+type PreparedG2 struct {
+	// lines is nil for the point at infinity
+	lines []lineCoeffs
 }
 
-// cyclotomicExp computes exponentiation in the cyclotomic subgroup
-func cyclotomicExp(f *Fp12, exp *big.Int) *Fp12 {
-	result := &Fp12{
-		c0: &Fp6{
-			c0: &Fp2{a: big.NewInt(1), b: big.NewInt(0)},
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
-		c1: &Fp6{
-			c0: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
+// PrepareG2 precomputes the Miller loop lines of q. q is assumed to be in
+// G2, as points returned by NewG2 and UnmarshalG2 are; check IsInSubgroup
+// first for points from other sources.
+//
+// The lines are those of the BN optimal ate pairing (Vercauteren, "Optimal
+// Pairings", section 6): f_{6u+2,Q} computed over the non-adjacent form of
+// 6u+2, which leaves the running point at T = [6u+2]Q, followed by the lines
+// through T and Q1 = π(Q), then T + Q1 and -Q2 = -π²(Q).
+func PrepareG2(q *G2) *PreparedG2 {
+	if q.IsInfinity() {
+		return &PreparedG2{}
 	}
 
-	base := f.Copy()
-	for i := 0; i < exp.BitLen(); i++ {
-		if exp.Bit(i) == 1 {
-			result = result.Mul(base)
+	n := len(sixUPlus2NAF) - 1 + 2
+	for _, d := range sixUPlus2NAF[:len(sixUPlus2NAF)-1] {
+		if d != 0 {
+			n++
 		}
-		base = cyclotomicSquare(base)
 	}
+	lines := make([]lineCoeffs, 0, n)
 
-	return result
-}
+	r := g2Proj{X: q.X, Y: q.Y}
+	r.Z.a = fpOne
+	minusQ := q.Neg()
 
-// frobeniusP computes the Frobenius endomorphism (raise to power p)
-func frobeniusP(f *Fp12) *Fp12 {
-	// For Fp2 elements (a + bu), Frobenius gives (a - bu)
-	c0 := &Fp6{
-		c0: &Fp2{a: f.c0.c0.a, b: new(big.Int).Neg(f.c0.c0.b).Mod(new(big.Int).Neg(f.c0.c0.b), P)},
-		c1: &Fp2{a: f.c0.c1.a, b: new(big.Int).Neg(f.c0.c1.b).Mod(new(big.Int).Neg(f.c0.c1.b), P)},
-		c2: &Fp2{a: f.c0.c2.a, b: new(big.Int).Neg(f.c0.c2.b).Mod(new(big.Int).Neg(f.c0.c2.b), P)},
+	for i := len(sixUPlus2NAF) - 2; i >= 0; i-- {
+		lines = append(lines, lineFunctionDouble(&r))
+		switch sixUPlus2NAF[i] {
+		case 1:
+			lines = append(lines, lineFunctionAdd(&r, q))
+		case -1:
+			lines = append(lines, lineFunctionAdd(&r, minusQ))
+		}
 	}
 
-	c1 := &Fp6{
-		c0: &Fp2{a: f.c1.c0.a, b: new(big.Int).Neg(f.c1.c0.b).Mod(new(big.Int).Neg(f.c1.c0.b), P)},
-		c1: &Fp2{a: f.c1.c1.a, b: new(big.Int).Neg(f.c1.c1.b).Mod(new(big.Int).Neg(f.c1.c1.b), P)},
-		c2: &Fp2{a: f.c1.c2.a, b: new(big.Int).Neg(f.c1.c2.b).Mod(new(big.Int).Neg(f.c1.c2.b), P)},
-	}
+	// π is the p-power Frobenius moved onto the twist, which is ψ
+	q1 := q.Psi()
+
+	// ξ^((p²-1)/2) = -1, so negating π²(Q) leaves y unchanged
+	minusQ2 := &G2{Y: q.Y}
+	fp2MulFp(&minusQ2.X, &q.X, xiToPSquaredMinus1Over3)
 
-	// Multiply by Frobenius coefficients
-	c0.c1 = c0.c1.Mul(xiToPMinus1Over6)
-	c0.c2 = c0.c2.Mul(xiToPMinus1Over3)
-	c1.c0 = c1.c0.Mul(xiToPMinus1Over6)
-	c1.c1 = c1.c1.Mul(xiToPMinus1Over3)
-	c1.c2 = c1.c2.Mul(xiToPMinus1Over6)
+	lines = append(lines, lineFunctionAdd(&r, q1))
+	lines = append(lines, lineFunctionAdd(&r, minusQ2))
 
-	return &Fp12{c0: c0, c1: c1}
+	return &PreparedG2{lines: lines}
 }
 
-// frobeniusP2 computes the Frobenius endomorphism raised to power 2
-func frobeniusP2(f *Fp12) *Fp12 {
-	// For Fp2, Frobenius^2 is identity on the base elements
-	// But we still need to multiply by appropriate powers
-	c0 := &Fp6{
-		c0: f.c0.c0.Copy(),
-		c1: f.c0.c1.MulScalar(fromHex("1284b71c2865a7dfe8b99fdd76e68b605c521e08292f2176d60b35dadcc9e470")),
-		c2: f.c0.c2.MulScalar(fromHex("246996f3b4fae7e6a6327cfe12150b8e747992778eeec7e5ca5cf05f80f362ac")),
-	}
+// IsInfinity reports whether p was prepared from the point at infinity
+func (p *PreparedG2) IsInfinity() bool {
+	return len(p.lines) == 0
+}
 
-	c1 := &Fp6{
-		c0: f.c1.c0.MulScalar(fromHex("1284b71c2865a7dfe8b99fdd76e68b605c521e08292f2176d60b35dadcc9e470")),
-		c1: f.c1.c1.MulScalar(fromHex("246996f3b4fae7e6a6327cfe12150b8e747992778eeec7e5ca5cf05f80f362ac")),
-		c2: f.c1.c2.MulScalar(fromHex("1284b71c2865a7dfe8b99fdd76e68b605c521e08292f2176d60b35dadcc9e470")),
+// MillerLoop returns the product of the Miller loops of the pairs
+// (g1s[i], g2s[i]), computed with one shared accumulator. The result is
+// only meaningful after FinalExponentiate: for ml, err := MillerLoop(g1s,
+// g2s) with err == nil, FinalExponentiate(ml) = Π e(g1s[i], g2s[i]). The
+// points must lie in G1 and G2 but are not validated; use PairingCheckPairs
+// for untrusted input. It returns ErrLengthMismatch if the slices differ in
+// length.
+func MillerLoop(g1s []*G1, g2s []*G2) (*GT, error) {
+	if len(g1s) != len(g2s) {
+		return nil, ErrLengthMismatch
 	}
+	return &GT{value: multiMillerLoop(g1s, g2s)}, nil
+}
 
-	return &Fp12{c0: c0, c1: c1}
+// FinalExponentiate raises the output of MillerLoop to the power
+// (p¹²-1)/Order, mapping it to the order-r subgroup of Fp12. The hard part
+// of the exponent is evaluated with exponentiations by the BN seed u; see
+// finalExponentiation.
+func FinalExponentiate(g *GT) *GT {
+	return &GT{value: finalExponentiation(g.value)}
 }
 
-// frobeniusP3 computes the Frobenius endomorphism raised to power 3
-func frobeniusP3(f *Fp12) *Fp12 {
-	c0 := &Fp6{
-		c0: &Fp2{a: f.c0.c0.a, b: new(big.Int).Neg(f.c0.c0.b).Mod(new(big.Int).Neg(f.c0.c0.b), P)},
-		c1: &Fp2{a: f.c0.c1.a, b: new(big.Int).Neg(f.c0.c1.b).Mod(new(big.Int).Neg(f.c0.c1.b), P)},
-		c2: &Fp2{a: f.c0.c2.a, b: new(big.Int).Neg(f.c0.c2.b).Mod(new(big.Int).Neg(f.c0.c2.b), P)},
+// finalExponentiation computes the (p¹²-1)/Order-th power of an element of
+// GF(p¹²) to obtain an element of GT. The exponent splits as
+// (p⁶-1)(p²+1)·(p⁴-p²+1)/Order. The easy part (p⁶-1)(p²+1) costs an
+// inversion and two Frobenius maps and leaves the cyclotomic subgroup; the
+// hard part (p⁴-p²+1)/Order is written in base p with coefficients that are
+// polynomials in u and evaluated with the vector addition chain of Scott et
+// al., "On the Final Exponentiation for Calculating Pairings on Ordinary
+// Elliptic Curves" (Pairing 2009): three exponentiations by u, Frobenius
+// maps and a dozen multiplications. The result is exactly f^((p¹²-1)/Order),
+// as in Cloudflare's bn256 and golang.org/x/crypto/bn256.
+func finalExponentiation(in *Fp12) *Fp12 {
+	// Easy part: f = in^(p⁶-1) = conj(in)/in, then f = f^(p²+1)
+	var f, t Fp12
+	fp12Conjugate(&f, in)
+	fp12Inverse(&t, in)
+	fp12Mul(&f, &f, &t)
+	fp12Frobenius(&t, &f, 2)
+	fp12Mul(&f, &f, &t)
+
+	// Hard part. Conjugation inverts in the cyclotomic subgroup.
+	fu := cyclotomicExp(&f, curveU)
+	fu2 := cyclotomicExp(fu, curveU)
+	fu3 := cyclotomicExp(fu2, curveU)
+
+	// y0 = f^p · f^p² · f^p³
+	var y0, y1, y2, y3, y4, y5, y6 Fp12
+	fp12Frobenius(&y0, &f, 1)
+	fp12Frobenius(&t, &f, 2)
+	fp12Mul(&y0, &y0, &t)
+	fp12Frobenius(&t, &f, 3)
+	fp12Mul(&y0, &y0, &t)
+
+	// y1 = 1/f, y2 = (f^u²)^p², y3 = 1/(f^u)^p
+	fp12Conjugate(&y1, &f)
+	fp12Frobenius(&y2, fu2, 2)
+	fp12Frobenius(&y3, fu, 1)
+	fp12Conjugate(&y3, &y3)
+
+	// y4 = 1/(f^u · (f^u²)^p), y5 = 1/f^u², y6 = 1/(f^u³ · (f^u³)^p)
+	fp12Frobenius(&t, fu2, 1)
+	fp12Mul(&y4, fu, &t)
+	fp12Conjugate(&y4, &y4)
+	fp12Conjugate(&y5, fu2)
+	fp12Frobenius(&t, fu3, 1)
+	fp12Mul(&y6, fu3, &t)
+	fp12Conjugate(&y6, &y6)
+
+	// Result = y0 · y1² · y2⁶ · y3¹² · y4¹⁸ · y5³⁰ · y6³⁶
+	var t0, t1 Fp12
+	fp12CyclotomicSquare(&t0, &y6)
+	fp12Mul(&t0, &t0, &y4)
+	fp12Mul(&t0, &t0, &y5)
+	fp12Mul(&t1, &y3, &y5)
+	fp12Mul(&t1, &t1, &t0)
+	fp12Mul(&t0, &t0, &y2)
+	fp12CyclotomicSquare(&t1, &t1)
+	fp12Mul(&t1, &t1, &t0)
+	fp12CyclotomicSquare(&t1, &t1)
+	fp12Mul(&t0, &t1, &y1)
+	fp12Mul(&t1, &t1, &y0)
+	fp12CyclotomicSquare(&t0, &t0)
+	fp12Mul(&t0, &t0, &t1)
+
+	return &t0
+}
+
+// fp12CyclotomicSquare sets z = x² for x in the cyclotomic subgroup, the
+// elements of order dividing p⁴ - p² + 1, which is where every value lands
+// after the easy part of the final exponentiation. It uses Granger and
+// Scott, "Faster Squaring in the Cyclotomic Subgroup of Sixth Degree
+// Extensions": viewing Fp12 as a cubic extension of Fp4 = Fp2[w³], each Fp4
+// coefficient costs three Fp2 squarings, 9 in total against the 12 Fp2
+// multiplications of fp12Square.
+func fp12CyclotomicSquare(z, x *Fp12) {
+	var t [9]Fp2
+
+	// x4² + x0², 2·x4·x0 with x0 = c0.c0, x4 = c1.c1
+	fp2Square(&t[0], &x.c1.c1)
+	fp2Square(&t[1], &x.c0.c0)
+	fp2Add(&t[6], &x.c1.c1, &x.c0.c0)
+	fp2Square(&t[6], &t[6])
+	fp2Sub(&t[6], &t[6], &t[0])
+	fp2Sub(&t[6], &t[6], &t[1])
+
+	// x2 = c0.c2, x3 = c1.c0
+	fp2Square(&t[2], &x.c0.c2)
+	fp2Square(&t[3], &x.c1.c0)
+	fp2Add(&t[7], &x.c0.c2, &x.c1.c0)
+	fp2Square(&t[7], &t[7])
+	fp2Sub(&t[7], &t[7], &t[2])
+	fp2Sub(&t[7], &t[7], &t[3])
+
+	// x5 = c1.c2, x1 = c0.c1
+	fp2Square(&t[4], &x.c1.c2)
+	fp2Square(&t[5], &x.c0.c1)
+	fp2Add(&t[8], &x.c1.c2, &x.c0.c1)
+	fp2Square(&t[8], &t[8])
+	fp2Sub(&t[8], &t[8], &t[4])
+	fp2Sub(&t[8], &t[8], &t[5])
+	fp2MulByNonResidue(&t[8], &t[8])
+
+	fp2MulByNonResidue(&t[0], &t[0])
+	fp2Add(&t[0], &t[0], &t[1])
+	fp2MulByNonResidue(&t[2], &t[2])
+	fp2Add(&t[2], &t[2], &t[3])
+	fp2MulByNonResidue(&t[4], &t[4])
+	fp2Add(&t[4], &t[4], &t[5])
+
+	// z0 = 3t0 - 2x0 and so on, z3 = 3t8 + 2x3 and so on
+	var r Fp12
+	cyclotomicSquareMinus(&r.c0.c0, &t[0], &x.c0.c0)
+	cyclotomicSquareMinus(&r.c0.c1, &t[2], &x.c0.c1)
+	cyclotomicSquareMinus(&r.c0.c2, &t[4], &x.c0.c2)
+	cyclotomicSquarePlus(&r.c1.c0, &t[8], &x.c1.c0)
+	cyclotomicSquarePlus(&r.c1.c1, &t[6], &x.c1.c1)
+	cyclotomicSquarePlus(&r.c1.c2, &t[7], &x.c1.c2)
+	*z = r
+}
+
+// cyclotomicSquareMinus sets z = 3t - 2x
+func cyclotomicSquareMinus(z, t, x *Fp2) {
+	var d Fp2
+	fp2Sub(&d, t, x)
+	fp2Double(&d, &d)
+	fp2Add(z, &d, t)
+}
+
+// cyclotomicSquarePlus sets z = 3t + 2x
+func cyclotomicSquarePlus(z, t, x *Fp2) {
+	var d Fp2
+	fp2Add(&d, t, x)
+	fp2Double(&d, &d)
+	fp2Add(z, &d, t)
+}
+
+// fp12CyclotomicSquareCompressed squares x in Karabina's compressed form
+// ("Squaring in Cyclotomic Subgroups", 2013). Writing the coefficients as
+// g0 = c0.c0, g1 = c0.c1, g2 = c0.c2, g3 = c1.c0, g4 = c1.c1, g5 = c1.c2,
+// only g1, g2, g3 and g5 are read and updated, with 4 Fp2 squarings and a
+// single Fp2 product; g0 and g4 of z are left stale and must be recovered
+// with fp12DecompressKarabina before z is used as a full element.
+func fp12CyclotomicSquareCompressed(z, x *Fp12) {
+	var t [7]Fp2
+	g1, g2, g3, g5 := x.c0.c1, x.c0.c2, x.c1.c0, x.c1.c2
+
+	// t5 = 2·g1·g5
+	fp2Square(&t[0], &g1)
+	fp2Square(&t[1], &g5)
+	fp2Add(&t[5], &g1, &g5)
+	fp2Square(&t[2], &t[5])
+	fp2Add(&t[3], &t[0], &t[1])
+	fp2Sub(&t[5], &t[2], &t[3])
+
+	fp2Add(&t[6], &g3, &g2)
+	fp2Square(&t[3], &t[6])
+	fp2Square(&t[2], &g3)
+
+	// g3' = 6ξ·g1·g5 + 2·g3
+	fp2MulByNonResidue(&t[6], &t[5])
+	fp2Add(&t[5], &t[6], &g3)
+	fp2Double(&t[5], &t[5])
+	fp2Add(&z.c1.c0, &t[5], &t[6])
+
+	// g2' = 3ξ·g5² + 3·g1² - 2·g2
+	fp2MulByNonResidue(&t[4], &t[1])
+	fp2Add(&t[5], &t[0], &t[4])
+	fp2Sub(&t[6], &t[5], &g2)
+	fp2Square(&t[1], &g2)
+	fp2Double(&t[6], &t[6])
+	fp2Add(&z.c0.c2, &t[6], &t[5])
+
+	// g1' = 3·g3² + 3ξ·g2² - 2·g1
+	fp2MulByNonResidue(&t[4], &t[1])
+	fp2Add(&t[5], &t[2], &t[4])
+	fp2Sub(&t[6], &t[5], &g1)
+	fp2Double(&t[6], &t[6])
+	fp2Add(&z.c0.c1, &t[6], &t[5])
+
+	// g5' = 6·g2·g3 + 2·g5
+	fp2Add(&t[0], &t[2], &t[1])
+	fp2Sub(&t[5], &t[3], &t[0])
+	fp2Add(&t[6], &t[5], &g5)
+	fp2Double(&t[6], &t[6])
+	fp2Add(&z.c1.c2, &t[5], &t[6])
+}
+
+// karabinaG4Fraction sets num/den to the fraction giving g4 of the
+// compressed element x: (ξ·g5² + 3·g1² - 2·g2) / 4·g3 if g3 ≠ 0, otherwise
+// 2·g1·g5 / g2. It reports false when g2 = g3 = 0, in which case x = 1.
+func karabinaG4Fraction(num, den *Fp2, x *Fp12) bool {
+	if x.c1.c0.IsZero() {
+		fp2Mul(num, &x.c0.c1, &x.c1.c2)
+		fp2Double(num, num)
+		*den = x.c0.c2
+		return !den.IsZero()
+	}
+
+	var t, g5sq Fp2
+	fp2Square(num, &x.c0.c1)
+	fp2Sub(&t, num, &x.c0.c2)
+	fp2Double(&t, &t)
+	fp2Add(&t, &t, num)
+	fp2Square(&g5sq, &x.c1.c2)
+	fp2MulByNonResidue(num, &g5sq)
+	fp2Add(num, num, &t)
+	fp2Double(den, &x.c1.c0)
+	fp2Double(den, den)
+	return true
+}
+
+// karabinaFinish sets g4 of x and recomputes g0 = ξ(2g4² + g3g5 - 3g1g2) + 1
+func karabinaFinish(x *Fp12, g4 *Fp2) {
+	var t1, t2 Fp2
+	x.c1.c1 = *g4
+	fp2Mul(&t1, &x.c0.c2, &x.c0.c1)
+	fp2Square(&t2, g4)
+	fp2Sub(&t2, &t2, &t1)
+	fp2Double(&t2, &t2)
+	fp2Sub(&t2, &t2, &t1)
+	fp2Mul(&t1, &x.c1.c0, &x.c1.c2)
+	fp2Add(&t2, &t2, &t1)
+	fp2MulByNonResidue(&x.c0.c0, &t2)
+	fpAdd(&x.c0.c0.a, &x.c0.c0.a, &fpOne)
+}
+
+// fp12DecompressKarabina recovers g0 and g4 of a compressed cyclotomic
+// element in place; it costs one Fp2 inversion
+func fp12DecompressKarabina(x *Fp12) {
+	var num, den Fp2
+	if !karabinaG4Fraction(&num, &den, x) {
+		*x = *fp12One()
+		return
+	}
+	fp2Inverse(&den, &den)
+	fp2Mul(&num, &num, &den)
+	karabinaFinish(x, &num)
+}
+
+// fp12BatchDecompressKarabina decompresses every element of xs in place,
+// sharing one Fp2 inversion among them with Montgomery's trick
+func fp12BatchDecompressKarabina(xs []*Fp12) {
+	num := make([]Fp2, len(xs))
+	den := make([]Fp2, len(xs))
+	isOne := make([]bool, len(xs))
+	for i, x := range xs {
+		isOne[i] = !karabinaG4Fraction(&num[i], &den[i], x)
+	}
+
+	fp2BatchInverse(den, den)
+	for i := range xs {
+		if isOne[i] {
+			*xs[i] = *fp12One()
+			continue
+		}
+		fp2Mul(&num[i], &num[i], &den[i])
+		karabinaFinish(xs[i], &num[i])
 	}
+}
 
-	c1 := &Fp6{
-		c0: &Fp2{a: f.c1.c0.a, b: new(big.Int).Neg(f.c1.c0.b).Mod(new(big.Int).Neg(f.c1.c0.b), P)},
-		c1: &Fp2{a: f.c1.c1.a, b: new(big.Int).Neg(f.c1.c1.b).Mod(new(big.Int).Neg(f.c1.c1.b), P)},
-		c2: &Fp2{a: f.c1.c2.a, b: new(big.Int).Neg(f.c1.c2.b).Mod(new(big.Int).Neg(f.c1.c2.b), P)},
+// cyclotomicExp computes f^exp for f in the cyclotomic subgroup and
+// exp ≥ 0. It performs all squarings in Karabina's compressed form, keeps
+// the powers f^(2^i) at the set bits of exp, decompresses them with one
+// shared inversion and multiplies them together.
+func cyclotomicExp(f *Fp12, exp *big.Int) *Fp12 {
+	result := fp12One()
+	n := exp.BitLen()
+	if n == 0 {
+		return result
 	}
 
-	// Multiply by Frobenius^3 coefficients
-	c0.c1 = c0.c1.Mul(&Fp2{
-		a: fromHex("5b54f5e64eea80180f3c0b75a181e84d33365f7be94ec72848a1f55921ea762"),
-		b: big.NewInt(0),
-	})
-	c0.c2 = c0.c2.Mul(&Fp2{
-		a: fromHex("5b54f5e64eea80180f3c0b75a181e84d33365f7be94ec72848a1f55921ea762"),
-		b: big.NewInt(0),
-	})
+	var powers []*Fp12
+	x := *f
+	for i := 1; i < n; i++ {
+		fp12CyclotomicSquareCompressed(&x, &x)
+		if exp.Bit(i) == 1 {
+			p := x
+			powers = append(powers, &p)
+		}
+	}
+	fp12BatchDecompressKarabina(powers)
 
-	return &Fp12{c0: c0, c1: c1}
+	if exp.Bit(0) == 1 {
+		*result = *f
+	}
+	for _, p := range powers {
+		fp12Mul(result, result, p)
+	}
+	return result
 }
 
-// Pair computes the optimal ate pairing e(p, q)
+// Frobenius computes f^(p^power), the power-th iterate of the p-power
+// Frobenius endomorphism. power may be any integer; it is reduced mod 12.
+func (f *Fp12) Frobenius(power int) *Fp12 {
+	z := f.Copy()
+	power %= 12
+	if power < 0 {
+		power += 12
+	}
+	for ; power >= 3; power -= 3 {
+		fp12Frobenius(z, z, 3)
+	}
+	if power > 0 {
+		fp12Frobenius(z, z, power)
+	}
+	return z
+}
+
+// fp12Frobenius sets z = x^(p^i) for i in 1..3. Writing x = Σ x_k·w^k with
+// x_k in Fp2 (w⁰ = c0.c0, w¹ = c1.c0, w² = c0.c1, w³ = c1.c1, w⁴ = c0.c2,
+// w⁵ = c1.c2), the Frobenius conjugates x_k when i is odd and scales it by
+// frobeniusCoeffs[i-1][k-1].
+func fp12Frobenius(z, x *Fp12, i int) {
+	coeffs := &frobeniusCoeffs[i-1]
+	*z = *x
+	if i%2 == 1 {
+		fp2Conjugate(&z.c0.c0, &z.c0.c0)
+		fp2Conjugate(&z.c0.c1, &z.c0.c1)
+		fp2Conjugate(&z.c0.c2, &z.c0.c2)
+		fp2Conjugate(&z.c1.c0, &z.c1.c0)
+		fp2Conjugate(&z.c1.c1, &z.c1.c1)
+		fp2Conjugate(&z.c1.c2, &z.c1.c2)
+
+		fp2Mul(&z.c1.c0, &z.c1.c0, &coeffs[0])
+		fp2Mul(&z.c0.c1, &z.c0.c1, &coeffs[1])
+		fp2Mul(&z.c1.c1, &z.c1.c1, &coeffs[2])
+		fp2Mul(&z.c0.c2, &z.c0.c2, &coeffs[3])
+		fp2Mul(&z.c1.c2, &z.c1.c2, &coeffs[4])
+		return
+	}
+
+	// For even i the coefficients are in Fp
+	fp2MulFp(&z.c1.c0, &z.c1.c0, &coeffs[0].a)
+	fp2MulFp(&z.c0.c1, &z.c0.c1, &coeffs[1].a)
+	fp2MulFp(&z.c1.c1, &z.c1.c1, &coeffs[2].a)
+	fp2MulFp(&z.c0.c2, &z.c0.c2, &coeffs[3].a)
+	fp2MulFp(&z.c1.c2, &z.c1.c2, &coeffs[4].a)
+}
+
+// Pair computes the optimal ate pairing e(p, q). q must lie in G2; Pair does
+// not check it, PairingCheckPairs does.
 func Pair(p *G1, q *G2) *GT {
 	f := millerLoop(p, q)
 	f = finalExponentiation(f)
 	return &GT{value: f}
 }
 
-// PairingCheck verifies if e(p1, q1) * e(p2, q2) * ... * e(pn, qn) = 1
-// This is used in zkSNARK verification (EIP-197)
-func PairingCheck(pairs [][2]interface{}) bool {
-	result := &Fp12{
-		c0: &Fp6{
-			c0: &Fp2{a: big.NewInt(1), b: big.NewInt(0)},
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
-		c1: &Fp6{
-			c0: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c1: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-			c2: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		},
+// PairPrepared computes e(p, q) for a G2 point prepared with PrepareG2
+func PairPrepared(p *G1, q *PreparedG2) *GT {
+	f := multiMillerLoopPrepared([]*G1{p}, []*PreparedG2{q})
+	f = finalExponentiation(f)
+	return &GT{value: f}
+}
+
+// PairingCheckPairs verifies that e(g1[0], g2[0]) · ... · e(g1[n-1], g2[n-1])
+// = 1, the check performed by zkSNARK verifiers and the EIP-197 precompile.
+// It returns ErrLengthMismatch if the slices differ in length and a
+// *PairingInputError if a point is nil, off the curve or outside its
+// prime-order subgroup. If the inputs are valid but the product is not one
+// it returns false with ErrInvalidPairing. An empty input passes.
+func PairingCheckPairs(g1 []*G1, g2 []*G2) (bool, error) {
+	if len(g1) != len(g2) {
+		return false, ErrLengthMismatch
 	}
 
-	for _, pair := range pairs {
+	for i := range g1 {
+		if g1[i] == nil || !g1[i].IsOnCurve() {
+			return false, &PairingInputError{Index: i, Group: "G1", Err: ErrInvalidPoint}
+		}
+		if g2[i] == nil || !g2[i].IsOnCurve() {
+			return false, &PairingInputError{Index: i, Group: "G2", Err: ErrInvalidPoint}
+		}
+		if !g2[i].IsInSubgroup() {
+			return false, &PairingInputError{Index: i, Group: "G2", Err: ErrNotInSubgroup}
+		}
+	}
+
+	if !finalExponentiation(multiMillerLoop(g1, g2)).IsOne() {
+		return false, ErrInvalidPairing
+	}
+	return true, nil
+}
+
+// PairingCheckPrepared is PairingCheckPairs for G2 points prepared with
+// PrepareG2. The G1 points are validated in the same way; the prepared
+// points were fixed when they were prepared.
+func PairingCheckPrepared(g1 []*G1, g2 []*PreparedG2) (bool, error) {
+	if len(g1) != len(g2) {
+		return false, ErrLengthMismatch
+	}
+
+	for i := range g1 {
+		if g1[i] == nil || !g1[i].IsOnCurve() {
+			return false, &PairingInputError{Index: i, Group: "G1", Err: ErrInvalidPoint}
+		}
+		if g2[i] == nil {
+			return false, &PairingInputError{Index: i, Group: "G2", Err: ErrInvalidPoint}
+		}
+	}
+
+	if !finalExponentiation(multiMillerLoopPrepared(g1, g2)).IsOne() {
+		return false, ErrInvalidPairing
+	}
+	return true, nil
+}
+
+// PairingCheck verifies if e(p1, q1) * e(p2, q2) * ... * e(pn, qn) = 1
+// This is used in zkSNARK verification (EIP-197). It is a wrapper around
+// PairingCheckPairs that returns false for malformed input; use
+// PairingCheckPairs to tell bad input apart from a failed check.
+func PairingCheck(pairs [][2]interface{}) bool {
+	g1 := make([]*G1, len(pairs))
+	g2 := make([]*G2, len(pairs))
+	for i, pair := range pairs {
 		p, ok1 := pair[0].(*G1)
 		q, ok2 := pair[1].(*G2)
 		if !ok1 || !ok2 {
 			return false
 		}
-
-		f := millerLoop(p, q)
-		result = result.Mul(f)
+		g1[i], g2[i] = p, q
 	}
 
-	result = finalExponentiation(result)
-	return result.IsOne()
+	ok, _ := PairingCheckPairs(g1, g2)
+	return ok
 }
 
 // ============================================================================
@@ -1410,12 +3494,7 @@ func (g *GT) Inverse() *GT {
 
 // Equal checks if two GT elements are equal
 func (g *GT) Equal(h *GT) bool {
-	return g.value.c0.c0.Equal(h.value.c0.c0) &&
-		g.value.c0.c1.Equal(h.value.c0.c1) &&
-		g.value.c0.c2.Equal(h.value.c0.c2) &&
-		g.value.c1.c0.Equal(h.value.c1.c0) &&
-		g.value.c1.c1.Equal(h.value.c1.c1) &&
-		g.value.c1.c2.Equal(h.value.c1.c2)
+	return *g.value == *h.value
 }
 
 // IsOne checks if g == 1
@@ -1429,49 +3508,39 @@ func (g *GT) Marshal() []byte {
 	offset := 0
 
 	writeFp2 := func(f *Fp2) {
-		aBytes := f.a.Bytes()
-		bBytes := f.b.Bytes()
-		copy(buf[offset+32-len(aBytes):offset+32], aBytes)
-		copy(buf[offset+64-len(bBytes):offset+64], bBytes)
+		fpPutBytes(buf[offset:offset+32], &f.a)
+		fpPutBytes(buf[offset+32:offset+64], &f.b)
 		offset += 64
 	}
 
-	writeFp2(g.value.c0.c0)
-	writeFp2(g.value.c0.c1)
-	writeFp2(g.value.c0.c2)
-	writeFp2(g.value.c1.c0)
-	writeFp2(g.value.c1.c1)
-	writeFp2(g.value.c1.c2)
+	writeFp2(&g.value.c0.c0)
+	writeFp2(&g.value.c0.c1)
+	writeFp2(&g.value.c0.c2)
+	writeFp2(&g.value.c1.c0)
+	writeFp2(&g.value.c1.c1)
+	writeFp2(&g.value.c1.c2)
 
 	return buf
 }
 
-// UnmarshalGT deserializes a GT element
+// UnmarshalGT deserializes a GT element, rejecting coefficients that are
+// not below p with ErrNonCanonical
 func UnmarshalGT(buf []byte) (*GT, error) {
 	if len(buf) != 384 {
 		return nil, ErrInvalidEncoding
 	}
 
-	readFp2 := func(offset int) *Fp2 {
-		a := new(big.Int).SetBytes(buf[offset : offset+32])
-		b := new(big.Int).SetBytes(buf[offset+32 : offset+64])
-		return NewFp2(a, b)
-	}
-
-	return &GT{
-		value: &Fp12{
-			c0: &Fp6{
-				c0: readFp2(0),
-				c1: readFp2(64),
-				c2: readFp2(128),
-			},
-			c1: &Fp6{
-				c0: readFp2(192),
-				c1: readFp2(256),
-				c2: readFp2(320),
-			},
-		},
-	}, nil
+	v := new(Fp12)
+	coeffs := []*Fp2{&v.c0.c0, &v.c0.c1, &v.c0.c2, &v.c1.c0, &v.c1.c1, &v.c1.c2}
+	for i, c := range coeffs {
+		offset := 64 * i
+		if !fpSetCanonical(&c.a, buf[offset:offset+32]) ||
+			!fpSetCanonical(&c.b, buf[offset+32:offset+64]) {
+			return nil, ErrNonCanonical
+		}
+	}
+
+	return &GT{value: v}, nil
 }
 
 // ============================================================================
@@ -1493,7 +3562,7 @@ func RandomG2(rand io.Reader) (*G2, error) {
 	if err != nil {
 		return nil, err
 	}
-	return G2Generator().ScalarMult(k), nil
+	return ScalarBaseMultG2(k), nil
 }
 
 // randomScalar generates a random scalar in [1, Order)
@@ -1514,16 +3583,3 @@ func randomScalar(reader io.Reader) (*big.Int, error) {
 
 	return k, nil
 }
-
-// HashToG1 maps arbitrary data to a G1 point (simplified version)
-// Note: This is NOT a secure hash-to-curve. Use proper hash-to-curve for production.
-func HashToG1(data []byte) *G1 {
-	// This is a placeholder. Production code should use proper hash-to-curve
-	// algorithms like the one specified in draft-irtf-cfrg-hash-to-curve
-	h := new(big.Int).SetBytes(data)
-	h.Mod(h, Order)
-	if h.Sign() == 0 {
-		h = big.NewInt(1)
-	}
-	return ScalarBaseMult(h)
-}
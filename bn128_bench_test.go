@@ -3,6 +3,7 @@ package gobn128
 import (
 	"crypto/rand"
 	"math/big"
+	"runtime"
 	"testing"
 )
 
@@ -39,6 +40,54 @@ func BenchmarkFpSquare(b *testing.B) {
 	}
 }
 
+func BenchmarkFpMulGeneric(b *testing.B) {
+	x := NewFp(big.NewInt(12345))
+	y := NewFp(big.NewInt(67890))
+	var z Fp
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fpMulGeneric(&z, x, y)
+	}
+}
+
+func BenchmarkFpMulADX(b *testing.B) {
+	if !supportADX {
+		b.Skip("assembly Montgomery kernels not in use on this CPU")
+	}
+	x := NewFp(big.NewInt(12345))
+	y := NewFp(big.NewInt(67890))
+	var z Fp
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fpMul(&z, x, y)
+	}
+}
+
+func BenchmarkFpSquareGeneric(b *testing.B) {
+	x := NewFp(big.NewInt(12345))
+	var z Fp
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fpMulGeneric(&z, x, x)
+	}
+}
+
+func BenchmarkFpSquareADX(b *testing.B) {
+	if !supportADX {
+		b.Skip("assembly Montgomery kernels not in use on this CPU")
+	}
+	x := NewFp(big.NewInt(12345))
+	var z Fp
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fpSquare(&z, x)
+	}
+}
+
 func BenchmarkFpInverse(b *testing.B) {
 	x := NewFp(big.NewInt(12345))
 
@@ -48,6 +97,51 @@ func BenchmarkFpInverse(b *testing.B) {
 	}
 }
 
+func BenchmarkFpSqrt(b *testing.B) {
+	// 4 = 2² is a square
+	x := NewFp(big.NewInt(4))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Sqrt()
+	}
+}
+
+// ============================================================================
+// Fr Benchmarks
+// ============================================================================
+
+func BenchmarkFrMul(b *testing.B) {
+	x := NewFr(big.NewInt(12345))
+	y := NewFr(big.NewInt(67890))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.Mul(y)
+	}
+}
+
+func BenchmarkFrInverse(b *testing.B) {
+	x := NewFr(big.NewInt(12345))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.Inverse()
+	}
+}
+
+func BenchmarkBatchInverseFr256(b *testing.B) {
+	xs := make([]*Fr, 256)
+	for i := range xs {
+		xs[i] = NewFr(big.NewInt(int64(i + 1)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = BatchInverseFr(xs)
+	}
+}
+
 // ============================================================================
 // Fp2 Benchmarks
 // ============================================================================
@@ -90,6 +184,15 @@ func BenchmarkFp2Inverse(b *testing.B) {
 	}
 }
 
+func BenchmarkFp2Sqrt(b *testing.B) {
+	x := NewFp2(big.NewInt(12345), big.NewInt(67890)).Square()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Sqrt()
+	}
+}
+
 // ============================================================================
 // Fp6 Benchmarks
 // ============================================================================
@@ -218,6 +321,29 @@ func BenchmarkFp12Mul(b *testing.B) {
 	}
 }
 
+func BenchmarkFp12MulBy034(b *testing.B) {
+	c00 := NewFp2(big.NewInt(1), big.NewInt(2))
+	c01 := NewFp2(big.NewInt(3), big.NewInt(4))
+	c02 := NewFp2(big.NewInt(5), big.NewInt(6))
+	c0 := NewFp6(c00, c01, c02)
+
+	c10 := NewFp2(big.NewInt(7), big.NewInt(8))
+	c11 := NewFp2(big.NewInt(9), big.NewInt(10))
+	c12 := NewFp2(big.NewInt(11), big.NewInt(12))
+	c1 := NewFp6(c10, c11, c12)
+
+	x := NewFp12(c0, c1)
+
+	d0 := NewFp2(big.NewInt(13), big.NewInt(14))
+	d3 := NewFp2(big.NewInt(19), big.NewInt(20))
+	d4 := NewFp2(big.NewInt(21), big.NewInt(22))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.MulBy034(d0, d3, d4)
+	}
+}
+
 func BenchmarkFp12Square(b *testing.B) {
 	c00 := NewFp2(big.NewInt(1), big.NewInt(2))
 	c01 := NewFp2(big.NewInt(3), big.NewInt(4))
@@ -237,6 +363,35 @@ func BenchmarkFp12Square(b *testing.B) {
 	}
 }
 
+func BenchmarkFp12CyclotomicSquare(b *testing.B) {
+	x := randomCyclotomic(b)
+	var z Fp12
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fp12CyclotomicSquare(&z, x)
+	}
+}
+
+func BenchmarkFp12CyclotomicSquareCompressed(b *testing.B) {
+	x := randomCyclotomic(b)
+	var z Fp12
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fp12CyclotomicSquareCompressed(&z, x)
+	}
+}
+
+func BenchmarkCyclotomicExpU(b *testing.B) {
+	x := randomCyclotomic(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = cyclotomicExp(x, curveU)
+	}
+}
+
 func BenchmarkFp12Inverse(b *testing.B) {
 	c00 := NewFp2(big.NewInt(1), big.NewInt(2))
 	c01 := NewFp2(big.NewInt(3), big.NewInt(4))
@@ -299,6 +454,53 @@ func BenchmarkG1Double(b *testing.B) {
 	}
 }
 
+func BenchmarkG1JacAdd(b *testing.B) {
+	g := new(G1Jac).FromAffine(G1Generator())
+	p := g.Double()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.Add(p)
+	}
+}
+
+func BenchmarkG1JacDouble(b *testing.B) {
+	g := new(G1Jac).FromAffine(G1Generator())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.Double()
+	}
+}
+
+func BenchmarkG1JacToAffine256(b *testing.B) {
+	ps := make([]*G1Jac, 256)
+	ps[0] = new(G1Jac).FromAffine(G1Generator()).Double()
+	for i := 1; i < len(ps); i++ {
+		ps[i] = ps[i-1].Double()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range ps {
+			_ = p.ToAffine()
+		}
+	}
+}
+
+func BenchmarkBatchNormalizeG1256(b *testing.B) {
+	ps := make([]*G1Jac, 256)
+	ps[0] = new(G1Jac).FromAffine(G1Generator()).Double()
+	for i := 1; i < len(ps); i++ {
+		ps[i] = ps[i-1].Double()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = BatchNormalizeG1(ps)
+	}
+}
+
 func BenchmarkG1ScalarMult(b *testing.B) {
 	g := G1Generator()
 	scalar, _ := randomScalar(rand.Reader)
@@ -309,6 +511,16 @@ func BenchmarkG1ScalarMult(b *testing.B) {
 	}
 }
 
+func BenchmarkG1ScalarMultBinary(b *testing.B) {
+	g := G1Generator()
+	scalar, _ := randomScalar(rand.Reader)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g1ScalarMultBinary(g, scalar)
+	}
+}
+
 func BenchmarkG1ScalarMultSmall(b *testing.B) {
 	g := G1Generator()
 	scalar := big.NewInt(12345)
@@ -330,8 +542,19 @@ func BenchmarkG1ScalarMultLarge(b *testing.B) {
 	}
 }
 
+func BenchmarkG1ScalarMultCT(b *testing.B) {
+	g := G1Generator()
+	k, _ := RandomFr(rand.Reader)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.ScalarMultCT(k)
+	}
+}
+
 func BenchmarkG1ScalarBaseMult(b *testing.B) {
 	scalar, _ := randomScalar(rand.Reader)
+	_ = ScalarBaseMult(scalar)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -339,6 +562,15 @@ func BenchmarkG1ScalarBaseMult(b *testing.B) {
 	}
 }
 
+func BenchmarkNewFixedBaseG1(b *testing.B) {
+	g := G1Generator()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = NewFixedBaseG1(g)
+	}
+}
+
 func BenchmarkG1Marshal(b *testing.B) {
 	g := G1Generator()
 
@@ -358,6 +590,16 @@ func BenchmarkG1Unmarshal(b *testing.B) {
 	}
 }
 
+func BenchmarkG1UnmarshalCompressed(b *testing.B) {
+	g := G1Generator()
+	buf := g.MarshalCompressed()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = UnmarshalCompressedG1(buf)
+	}
+}
+
 func BenchmarkG1IsOnCurve(b *testing.B) {
 	g := G1Generator()
 
@@ -399,6 +641,25 @@ func BenchmarkG2Double(b *testing.B) {
 	}
 }
 
+func BenchmarkG2JacAdd(b *testing.B) {
+	g := new(G2Jac).FromAffine(G2Generator())
+	p := g.Double()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.Add(p)
+	}
+}
+
+func BenchmarkG2JacDouble(b *testing.B) {
+	g := new(G2Jac).FromAffine(G2Generator())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.Double()
+	}
+}
+
 func BenchmarkG2ScalarMult(b *testing.B) {
 	g := G2Generator()
 	scalar, _ := randomScalar(rand.Reader)
@@ -409,6 +670,36 @@ func BenchmarkG2ScalarMult(b *testing.B) {
 	}
 }
 
+func BenchmarkG2ScalarMultCT(b *testing.B) {
+	g := G2Generator()
+	k, _ := RandomFr(rand.Reader)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.ScalarMultCT(k)
+	}
+}
+
+func BenchmarkG2ScalarBaseMult(b *testing.B) {
+	scalar, _ := randomScalar(rand.Reader)
+	_ = ScalarBaseMultG2(scalar)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ScalarBaseMultG2(scalar)
+	}
+}
+
+func BenchmarkG2ScalarMultBinary(b *testing.B) {
+	g := G2Generator()
+	scalar, _ := randomScalar(rand.Reader)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g2ScalarMultBinary(g, scalar)
+	}
+}
+
 func BenchmarkG2ScalarMultSmall(b *testing.B) {
 	g := G2Generator()
 	scalar := big.NewInt(12345)
@@ -438,6 +729,16 @@ func BenchmarkG2Unmarshal(b *testing.B) {
 	}
 }
 
+func BenchmarkG2UnmarshalCompressed(b *testing.B) {
+	g := G2Generator()
+	buf := g.MarshalCompressed()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = UnmarshalCompressedG2(buf)
+	}
+}
+
 func BenchmarkG2IsOnCurve(b *testing.B) {
 	g := G2Generator()
 
@@ -447,6 +748,15 @@ func BenchmarkG2IsOnCurve(b *testing.B) {
 	}
 }
 
+func BenchmarkG2IsInSubgroup(b *testing.B) {
+	g := G2Generator()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.IsInSubgroup()
+	}
+}
+
 func BenchmarkG2Neg(b *testing.B) {
 	g := G2Generator()
 
@@ -501,6 +811,38 @@ func BenchmarkFinalExponentiation(b *testing.B) {
 	}
 }
 
+func BenchmarkMillerLoop4Separate(b *testing.B) {
+	g1s, g2s := benchPairs(4)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f := fp12One()
+		for j := range g1s {
+			fp12Mul(f, f, millerLoop(g1s[j], g2s[j]))
+		}
+	}
+}
+
+func BenchmarkMillerLoop4Shared(b *testing.B) {
+	g1s, g2s := benchPairs(4)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = MillerLoop(g1s, g2s)
+	}
+}
+
+// benchPairs returns n distinct (G1, G2) pairs
+func benchPairs(n int) ([]*G1, []*G2) {
+	g1s := make([]*G1, n)
+	g2s := make([]*G2, n)
+	for i := range g1s {
+		g1s[i] = G1Generator().ScalarMult(big.NewInt(int64(i + 2)))
+		g2s[i] = G2Generator().ScalarMult(big.NewInt(int64(i + 3)))
+	}
+	return g1s, g2s
+}
+
 func BenchmarkPairingCheck2(b *testing.B) {
 	g1 := G1Generator()
 	g2 := G2Generator()
@@ -617,6 +959,61 @@ func BenchmarkGTUnmarshal(b *testing.B) {
 	}
 }
 
+// ============================================================================
+// Multi-Scalar Multiplication Benchmarks
+// ============================================================================
+
+func benchmarkMSMG1(b *testing.B, n, workers int) {
+	points := make([]*G1, n)
+	scalars := make([]*big.Int, n)
+	for i := range points {
+		points[i], _ = RandomG1(rand.Reader)
+		scalars[i], _ = rand.Int(rand.Reader, Order)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = MultiScalarMulG1Workers(points, scalars, workers)
+	}
+}
+
+func BenchmarkMultiScalarMulG1_1000(b *testing.B) { benchmarkMSMG1(b, 1000, 1) }
+func BenchmarkMultiScalarMulG1_1000Parallel(b *testing.B) {
+	benchmarkMSMG1(b, 1000, runtime.GOMAXPROCS(0))
+}
+func BenchmarkMultiScalarMulG1_10000(b *testing.B) { benchmarkMSMG1(b, 10000, 1) }
+
+func BenchmarkMultiScalarMulG1Naive_1000(b *testing.B) {
+	points := make([]*G1, 1000)
+	scalars := make([]*big.Int, 1000)
+	for i := range points {
+		points[i], _ = RandomG1(rand.Reader)
+		scalars[i], _ = rand.Int(rand.Reader, Order)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		acc := &G1{}
+		for j := range points {
+			acc = acc.Add(points[j].ScalarMult(scalars[j]))
+		}
+	}
+}
+
+func BenchmarkMultiScalarMulG2_1000(b *testing.B) {
+	points := make([]*G2, 1000)
+	scalars := make([]*big.Int, 1000)
+	for i := range points {
+		points[i], _ = RandomG2(rand.Reader)
+		scalars[i], _ = rand.Int(rand.Reader, Order)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = MultiScalarMulG2(points, scalars)
+	}
+}
+
 // ============================================================================
 // Random Generation Benchmarks
 // ============================================================================
@@ -648,10 +1045,41 @@ func BenchmarkRandomScalar(b *testing.B) {
 
 func BenchmarkHashToG1(b *testing.B) {
 	data := []byte("benchmark test data for hashing to G1")
+	dst := []byte("QUUX-V01-CS02-with-BN254G1_XMD:SHA-256_SVDW_RO_")
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = HashToG1(data)
+		_ = HashToG1(data, dst)
+	}
+}
+
+func BenchmarkEncodeToG1(b *testing.B) {
+	data := []byte("benchmark test data for hashing to G1")
+	dst := []byte("QUUX-V01-CS02-with-BN254G1_XMD:SHA-256_SVDW_NU_")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = EncodeToG1(data, dst)
+	}
+}
+
+func BenchmarkHashToG2(b *testing.B) {
+	data := []byte("benchmark test data for hashing to G2")
+	dst := []byte("QUUX-V01-CS02-with-BN254G2_XMD:SHA-256_SVDW_RO_")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = HashToG2(data, dst)
+	}
+}
+
+func BenchmarkG2ClearCofactor(b *testing.B) {
+	u := hashToFp2([]byte("benchmark test data"), []byte("BENCH"), 1)
+	p := svdwMapG2(&u[0])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = p.ClearCofactor()
 	}
 }
 
@@ -684,6 +1112,49 @@ func BenchmarkZKSNARKVerification(b *testing.B) {
 	}
 }
 
+// BenchmarkZKSNARKVerificationPrepared runs the same check as
+// BenchmarkZKSNARKVerification with the verifying key elements prepared
+// once, as a verifier with a fixed key would
+func BenchmarkZKSNARKVerificationPrepared(b *testing.B) {
+	g1 := G1Generator()
+	g2 := G2Generator()
+
+	proofA := g1.ScalarMult(big.NewInt(123))
+	proofB := g2.ScalarMult(big.NewInt(456))
+	proofC := g1.ScalarMult(big.NewInt(789))
+
+	alpha := g1.ScalarMult(big.NewInt(111))
+	beta := PrepareG2(g2.ScalarMult(big.NewInt(222)))
+	gamma := PrepareG2(g2)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = PairingCheckPrepared(
+			[]*G1{proofA, alpha, proofC},
+			[]*PreparedG2{PrepareG2(proofB), beta, gamma},
+		)
+	}
+}
+
+func BenchmarkPrepareG2(b *testing.B) {
+	g2 := G2Generator()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = PrepareG2(g2)
+	}
+}
+
+func BenchmarkPairPrepared(b *testing.B) {
+	g1 := G1Generator()
+	q := PrepareG2(G2Generator())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = PairPrepared(g1, q)
+	}
+}
+
 // ============================================================================
 // Memory Allocation Benchmarks
 // ============================================================================
@@ -2,6 +2,8 @@ package gobn128
 
 import (
 	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"math/big"
 	"testing"
 )
@@ -16,26 +18,26 @@ func TestFpArithmetic(t *testing.T) {
 
 	// Test Addition
 	sum := a.Add(b)
-	if sum.n.Cmp(big.NewInt(30)) != 0 {
-		t.Errorf("Addition failed: expected 30, got %s", sum.n.String())
+	if sum.BigInt().Cmp(big.NewInt(30)) != 0 {
+		t.Errorf("Addition failed: expected 30, got %s", sum.BigInt().String())
 	}
 
 	// Test Subtraction
 	diff := b.Sub(a)
-	if diff.n.Cmp(big.NewInt(10)) != 0 {
-		t.Errorf("Subtraction failed: expected 10, got %s", diff.n.String())
+	if diff.BigInt().Cmp(big.NewInt(10)) != 0 {
+		t.Errorf("Subtraction failed: expected 10, got %s", diff.BigInt().String())
 	}
 
 	// Test Multiplication
 	prod := a.Mul(b)
-	if prod.n.Cmp(big.NewInt(200)) != 0 {
-		t.Errorf("Multiplication failed: expected 200, got %s", prod.n.String())
+	if prod.BigInt().Cmp(big.NewInt(200)) != 0 {
+		t.Errorf("Multiplication failed: expected 200, got %s", prod.BigInt().String())
 	}
 
 	// Test Inverse
 	inv := a.Inverse()
 	product := a.Mul(inv)
-	if product.n.Cmp(big.NewInt(1)) != 0 {
+	if product.BigInt().Cmp(big.NewInt(1)) != 0 {
 		t.Errorf("Inverse failed: a * a^(-1) should equal 1")
 	}
 }
@@ -44,8 +46,8 @@ func TestFpModularReduction(t *testing.T) {
 	// Test that values are properly reduced modulo P
 	large := new(big.Int).Add(P, big.NewInt(5))
 	f := NewFp(large)
-	if f.n.Cmp(big.NewInt(5)) != 0 {
-		t.Errorf("Modular reduction failed: expected 5, got %s", f.n.String())
+	if f.BigInt().Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("Modular reduction failed: expected 5, got %s", f.BigInt().String())
 	}
 }
 
@@ -65,10 +67,163 @@ func TestFpSquare(t *testing.T) {
 	expected := a.Mul(a)
 
 	if !square.Equal(expected) {
-		t.Errorf("Square failed: got %s, expected %s", square.n.String(), expected.n.String())
+		t.Errorf("Square failed: got %s, expected %s", square.BigInt().String(), expected.BigInt().String())
 	}
 }
 
+func TestFpMontgomeryConstants(t *testing.T) {
+	R := new(big.Int).Lsh(big.NewInt(1), 256)
+
+	if NewFp(P).BigInt().Sign() != 0 {
+		t.Errorf("P should reduce to zero")
+	}
+	if fpModulus != *(*Fp)(limbsOf(P)) {
+		t.Errorf("fpModulus does not match P")
+	}
+	if fpOne != *(*Fp)(limbsOf(new(big.Int).Mod(R, P))) {
+		t.Errorf("fpOne should be R mod p")
+	}
+	r2 := new(big.Int).Mul(R, R)
+	if fpR2 != *(*Fp)(limbsOf(r2.Mod(r2, P))) {
+		t.Errorf("fpR2 should be R² mod p")
+	}
+	inv := new(big.Int).ModInverse(P, new(big.Int).Lsh(big.NewInt(1), 64))
+	inv.Neg(inv).Mod(inv, new(big.Int).Lsh(big.NewInt(1), 64))
+	if inv.Uint64() != fpInvNeg {
+		t.Errorf("fpInvNeg should be -p⁻¹ mod 2^64")
+	}
+}
+
+func TestFpRandomAgainstBigInt(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		x, _ := rand.Int(rand.Reader, P)
+		y, _ := rand.Int(rand.Reader, P)
+		a, b := NewFp(x), NewFp(y)
+
+		if a.BigInt().Cmp(x) != 0 {
+			t.Fatalf("Montgomery round trip failed for %s", x)
+		}
+
+		sum := new(big.Int).Add(x, y)
+		if a.Add(b).BigInt().Cmp(sum.Mod(sum, P)) != 0 {
+			t.Errorf("Add mismatch for %s + %s", x, y)
+		}
+		diff := new(big.Int).Sub(x, y)
+		if a.Sub(b).BigInt().Cmp(diff.Mod(diff, P)) != 0 {
+			t.Errorf("Sub mismatch for %s - %s", x, y)
+		}
+		prod := new(big.Int).Mul(x, y)
+		if a.Mul(b).BigInt().Cmp(prod.Mod(prod, P)) != 0 {
+			t.Errorf("Mul mismatch for %s * %s", x, y)
+		}
+		sq := new(big.Int).Mul(x, x)
+		if a.Square().BigInt().Cmp(sq.Mod(sq, P)) != 0 {
+			t.Errorf("Square mismatch for %s", x)
+		}
+		neg := new(big.Int).Neg(x)
+		if a.Neg().BigInt().Cmp(neg.Mod(neg, P)) != 0 {
+			t.Errorf("Neg mismatch for %s", x)
+		}
+		if x.Sign() != 0 && a.Inverse().BigInt().Cmp(new(big.Int).ModInverse(x, P)) != 0 {
+			t.Errorf("Inverse mismatch for %s", x)
+		}
+	}
+}
+
+func TestFpEdgeValues(t *testing.T) {
+	pMinus1 := new(big.Int).Sub(P, big.NewInt(1))
+	a := NewFp(pMinus1)
+
+	// (p-1)² = 1
+	if a.Square().BigInt().Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("(p-1)² should equal 1")
+	}
+	// (p-1) + 1 = 0
+	if !a.Add(NewFp(big.NewInt(1))).IsZero() {
+		t.Errorf("(p-1) + 1 should equal 0")
+	}
+	// Negative inputs are reduced
+	if NewFp(big.NewInt(-1)).BigInt().Cmp(pMinus1) != 0 {
+		t.Errorf("NewFp(-1) should equal p-1")
+	}
+	// Inverse of zero is zero by convention
+	if !new(Fp).Inverse().IsZero() {
+		t.Errorf("Inverse of zero should be zero")
+	}
+}
+
+func TestFpSqrtAndLegendre(t *testing.T) {
+	if NewFp(big.NewInt(0)).Legendre() != 0 {
+		t.Errorf("Legendre(0) should be 0")
+	}
+	for i := 0; i < 100; i++ {
+		x, _ := rand.Int(rand.Reader, P)
+		a := NewFp(x)
+
+		want := big.Jacobi(x, P)
+		if got := a.Legendre(); got != want {
+			t.Fatalf("Legendre(%s) = %d, expected %d", x, got, want)
+		}
+
+		r, ok := a.Sqrt()
+		if ok != (want >= 0) {
+			t.Fatalf("Sqrt(%s) reported %v for Legendre symbol %d", x, ok, want)
+		}
+		if ok && !r.Square().Equal(a) {
+			t.Errorf("Sqrt(%s)² != %s", x, x)
+		}
+	}
+}
+
+func TestFpMulAsmMatchesGeneric(t *testing.T) {
+	if !supportADX {
+		t.Skip("assembly Montgomery kernels not in use on this CPU")
+	}
+
+	pMinus1 := NewFp(new(big.Int).Sub(P, big.NewInt(1)))
+	inputs := []*Fp{new(Fp), NewFp(big.NewInt(1)), NewFp(big.NewInt(2)), pMinus1, &fpOne, &fpR2}
+	for i := 0; i < 1000; i++ {
+		x, _ := rand.Int(rand.Reader, P)
+		inputs = append(inputs, NewFp(x))
+	}
+
+	for i := range inputs {
+		x, y := inputs[i], inputs[(i*7+3)%len(inputs)]
+
+		var want, got Fp
+		fpMulGeneric(&want, x, y)
+		fpMul(&got, x, y)
+		if got != want {
+			t.Fatalf("fpMul mismatch for %s * %s", x.BigInt(), y.BigInt())
+		}
+
+		fpMulGeneric(&want, x, x)
+		fpSquare(&got, x)
+		if got != want {
+			t.Fatalf("fpSquare mismatch for %s", x.BigInt())
+		}
+
+		// Aliased output
+		got = *x
+		fpMul(&got, &got, y)
+		fpMulGeneric(&want, x, y)
+		if got != want {
+			t.Fatalf("aliased fpMul mismatch for %s * %s", x.BigInt(), y.BigInt())
+		}
+	}
+}
+
+// limbsOf splits a value below 2^256 into little-endian 64-bit limbs
+func limbsOf(n *big.Int) *[4]uint64 {
+	var buf [32]byte
+	n.FillBytes(buf[:])
+	var l [4]uint64
+	for i := 0; i < 4; i++ {
+		l[i] = new(big.Int).SetBytes(buf[24-8*i : 32-8*i]).Uint64()
+	}
+	return &l
+}
+
 // ============================================================================
 // Fp2 Tests
 // ============================================================================
@@ -79,7 +234,7 @@ func TestFp2Arithmetic(t *testing.T) {
 
 	// Test Addition
 	sum := a.Add(b)
-	if sum.a.Cmp(big.NewInt(8)) != 0 || sum.b.Cmp(big.NewInt(10)) != 0 {
+	if sum.a.BigInt().Cmp(big.NewInt(8)) != 0 || sum.b.BigInt().Cmp(big.NewInt(10)) != 0 {
 		t.Errorf("Fp2 addition failed")
 	}
 
@@ -87,11 +242,10 @@ func TestFp2Arithmetic(t *testing.T) {
 	// (3 + 4u)(5 + 6u) = 15 + 18u + 20u + 24u² = 15 + 38u - 24 = -9 + 38u
 	prod := a.Mul(b)
 	expected := NewFp2(new(big.Int).Sub(big.NewInt(15), big.NewInt(24)), big.NewInt(38))
-	expected = NewFp2(new(big.Int).Mod(expected.a, P), new(big.Int).Mod(expected.b, P))
 
 	if !prod.Equal(expected) {
 		t.Errorf("Fp2 multiplication failed: got (%s, %s), expected (%s, %s)",
-			prod.a.String(), prod.b.String(), expected.a.String(), expected.b.String())
+			prod.a.BigInt().String(), prod.b.BigInt().String(), expected.a.BigInt().String(), expected.b.BigInt().String())
 	}
 }
 
@@ -120,14 +274,491 @@ func TestFp2Conjugate(t *testing.T) {
 	a := NewFp2(big.NewInt(3), big.NewInt(4))
 	neg := a.Neg()
 
-	if neg.a.Cmp(new(big.Int).Sub(P, big.NewInt(3))) != 0 {
+	if neg.a.BigInt().Cmp(new(big.Int).Sub(P, big.NewInt(3))) != 0 {
 		t.Errorf("Fp2 negation failed on real part")
 	}
-	if neg.b.Cmp(new(big.Int).Sub(P, big.NewInt(4))) != 0 {
+	if neg.b.BigInt().Cmp(new(big.Int).Sub(P, big.NewInt(4))) != 0 {
 		t.Errorf("Fp2 negation failed on imaginary part")
 	}
 }
 
+func TestFp2SqrtAndIsSquare(t *testing.T) {
+	squares := 0
+	for i := 0; i < 100; i++ {
+		a := randomFp2(t)
+		r, ok := a.Sqrt()
+		if ok != a.IsSquare() {
+			t.Fatalf("Sqrt and IsSquare disagree")
+		}
+		if ok {
+			squares++
+			if !r.Square().Equal(a) {
+				t.Errorf("Fp2 Sqrt(a)² != a")
+			}
+		}
+
+		sq := a.Square()
+		if !sq.IsSquare() {
+			t.Errorf("a² should be a square")
+		}
+		if r, ok := sq.Square().Sqrt(); !ok || !r.Square().Equal(sq.Square()) {
+			t.Errorf("Fp2 Sqrt failed on a square")
+		}
+	}
+	// Half of the non-zero elements are squares
+	if squares == 0 || squares == 100 {
+		t.Errorf("Got %d squares out of 100 random elements", squares)
+	}
+	if !new(Fp2).IsSquare() {
+		t.Errorf("0 should be a square")
+	}
+}
+
+// ============================================================================
+// Fr Tests
+// ============================================================================
+
+func TestFrMontgomeryConstants(t *testing.T) {
+	R := new(big.Int).Lsh(big.NewInt(1), 256)
+
+	if frModulus != *(*Fr)(limbsOf(Order)) {
+		t.Errorf("frModulus does not match Order")
+	}
+	if frOne != *(*Fr)(limbsOf(new(big.Int).Mod(R, Order))) {
+		t.Errorf("frOne should be R mod r")
+	}
+	r2 := new(big.Int).Mul(R, R)
+	if frR2 != *(*Fr)(limbsOf(r2.Mod(r2, Order))) {
+		t.Errorf("frR2 should be R² mod r")
+	}
+	inv := new(big.Int).ModInverse(Order, new(big.Int).Lsh(big.NewInt(1), 64))
+	inv.Neg(inv).Mod(inv, new(big.Int).Lsh(big.NewInt(1), 64))
+	if inv.Uint64() != frInvNeg {
+		t.Errorf("frInvNeg should be -r⁻¹ mod 2^64")
+	}
+	if !NewFr(Order).IsZero() {
+		t.Errorf("Order should reduce to zero")
+	}
+	if !NewFr(big.NewInt(1)).IsOne() {
+		t.Errorf("NewFr(1) should be one")
+	}
+}
+
+func TestFrRandomAgainstBigInt(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		x, _ := rand.Int(rand.Reader, Order)
+		y, _ := rand.Int(rand.Reader, Order)
+		a, b := NewFr(x), NewFr(y)
+
+		if a.BigInt().Cmp(x) != 0 {
+			t.Fatalf("Montgomery round trip failed for %s", x)
+		}
+
+		sum := new(big.Int).Add(x, y)
+		if a.Add(b).BigInt().Cmp(sum.Mod(sum, Order)) != 0 {
+			t.Errorf("Add mismatch for %s + %s", x, y)
+		}
+		diff := new(big.Int).Sub(x, y)
+		if a.Sub(b).BigInt().Cmp(diff.Mod(diff, Order)) != 0 {
+			t.Errorf("Sub mismatch for %s - %s", x, y)
+		}
+		prod := new(big.Int).Mul(x, y)
+		if a.Mul(b).BigInt().Cmp(prod.Mod(prod, Order)) != 0 {
+			t.Errorf("Mul mismatch for %s * %s", x, y)
+		}
+		sq := new(big.Int).Mul(x, x)
+		if a.Square().BigInt().Cmp(sq.Mod(sq, Order)) != 0 {
+			t.Errorf("Square mismatch for %s", x)
+		}
+		neg := new(big.Int).Neg(x)
+		if a.Neg().BigInt().Cmp(neg.Mod(neg, Order)) != 0 {
+			t.Errorf("Neg mismatch for %s", x)
+		}
+		if x.Sign() != 0 && a.Inverse().BigInt().Cmp(new(big.Int).ModInverse(x, Order)) != 0 {
+			t.Errorf("Inverse mismatch for %s", x)
+		}
+		if a.Exp(y).BigInt().Cmp(new(big.Int).Exp(x, y, Order)) != 0 {
+			t.Errorf("Exp mismatch for %s^%s", x, y)
+		}
+	}
+}
+
+func TestFrExpEdgeCases(t *testing.T) {
+	a := NewFr(big.NewInt(5))
+
+	if !a.Exp(big.NewInt(0)).IsOne() {
+		t.Errorf("a^0 should equal 1")
+	}
+	if !a.Exp(big.NewInt(-1)).Equal(a.Inverse()) {
+		t.Errorf("a^-1 should equal a⁻¹")
+	}
+	// Exponents beyond 256 bits: a^(r-1) = 1, so a^((r-1)·2^300 + 3) = a³
+	e := new(big.Int).Sub(Order, big.NewInt(1))
+	e.Lsh(e, 300).Add(e, big.NewInt(3))
+	if !a.Exp(e).Equal(a.Square().Mul(a)) {
+		t.Errorf("a^((r-1)·2^300 + 3) should equal a³")
+	}
+	if !new(Fr).Inverse().IsZero() {
+		t.Errorf("Inverse of zero should be zero")
+	}
+}
+
+func TestFrBytes(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		a, err := RandomFr(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := a.Bytes()
+		if new(big.Int).SetBytes(buf).Cmp(a.BigInt()) != 0 {
+			t.Fatalf("Bytes does not match BigInt for %s", a.BigInt())
+		}
+		b, err := new(Fr).SetBytes(buf)
+		if err != nil || !b.Equal(a) {
+			t.Fatalf("SetBytes round trip failed for %s: %v", a.BigInt(), err)
+		}
+	}
+
+	rMinus1 := new(big.Int).Sub(Order, big.NewInt(1))
+	if _, err := new(Fr).SetBytes(rMinus1.FillBytes(make([]byte, 32))); err != nil {
+		t.Errorf("SetBytes rejected r-1: %v", err)
+	}
+	for _, v := range []*big.Int{Order, new(big.Int).Add(Order, big.NewInt(1)), P} {
+		if _, err := new(Fr).SetBytes(v.FillBytes(make([]byte, 32))); !errors.Is(err, ErrNonCanonical) {
+			t.Errorf("SetBytes(%s) returned %v, expected ErrNonCanonical", v, err)
+		}
+	}
+	if _, err := new(Fr).SetBytes(make([]byte, 31)); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("SetBytes of 31 bytes returned %v, expected ErrInvalidEncoding", err)
+	}
+
+	// A failed SetBytes leaves the receiver unchanged
+	a := NewFr(big.NewInt(7))
+	_, _ = a.SetBytes(Order.FillBytes(make([]byte, 32)))
+	if a.BigInt().Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("Failed SetBytes modified the receiver")
+	}
+}
+
+func TestScalarMultFr(t *testing.T) {
+	g1 := G1Generator()
+	g2 := G2Generator()
+
+	for i := 0; i < 10; i++ {
+		k, err := RandomFr(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g1.ScalarMultFr(k).Equal(g1.ScalarMult(k.BigInt())) {
+			t.Errorf("G1 ScalarMultFr mismatch for %s", k.BigInt())
+		}
+		if !g2.ScalarMultFr(k).Equal(g2.ScalarMult(k.BigInt())) {
+			t.Errorf("G2 ScalarMultFr mismatch for %s", k.BigInt())
+		}
+	}
+
+	// -1 = r-1 in Fr
+	minusOne := NewFr(big.NewInt(-1))
+	if !g1.ScalarMultFr(minusOne).Equal(g1.Neg()) {
+		t.Errorf("G1 ScalarMultFr(-1) should equal -G1")
+	}
+	if !g2.ScalarMultFr(minusOne).Equal(g2.Neg()) {
+		t.Errorf("G2 ScalarMultFr(-1) should equal -G2")
+	}
+	if !g1.ScalarMultFr(new(Fr)).IsInfinity() {
+		t.Errorf("G1 ScalarMultFr(0) should be infinity")
+	}
+}
+
+// ============================================================================
+// Batch Inversion Tests
+// ============================================================================
+
+func TestBatchInverse(t *testing.T) {
+	var fps []*Fp
+	var fp2s []*Fp2
+	var frs []*Fr
+	for i := 0; i < 20; i++ {
+		// Every fifth element is zero, including the first and the last
+		if i%5 == 0 || i == 19 {
+			fps = append(fps, new(Fp))
+			fp2s = append(fp2s, new(Fp2))
+			frs = append(frs, new(Fr))
+			continue
+		}
+		x, _ := rand.Int(rand.Reader, P)
+		y, _ := rand.Int(rand.Reader, P)
+		fps = append(fps, NewFp(x))
+		fp2s = append(fp2s, NewFp2(x, y))
+		k, _ := RandomFr(rand.Reader)
+		frs = append(frs, k)
+	}
+	// Elements of Fp2 with a single zero half are not zero
+	fp2s[1] = NewFp2(big.NewInt(0), big.NewInt(5))
+
+	invFp := BatchInverseFp(fps)
+	invFp2 := BatchInverseFp2(fp2s)
+	invFr := BatchInverseFr(frs)
+	for i := range fps {
+		if !invFp[i].Equal(fps[i].Inverse()) {
+			t.Errorf("BatchInverseFp mismatch at %d", i)
+		}
+		if !invFp2[i].Equal(fp2s[i].Inverse()) {
+			t.Errorf("BatchInverseFp2 mismatch at %d", i)
+		}
+		if !invFr[i].Equal(frs[i].Inverse()) {
+			t.Errorf("BatchInverseFr mismatch at %d", i)
+		}
+	}
+
+	// Inputs are left untouched
+	if !fps[2].Mul(invFp[2]).Equal(NewFp(big.NewInt(1))) {
+		t.Errorf("BatchInverseFp modified its input")
+	}
+
+	if len(BatchInverseFp(nil)) != 0 || len(BatchInverseFp2(nil)) != 0 || len(BatchInverseFr(nil)) != 0 {
+		t.Errorf("Batch inversion of an empty slice should be empty")
+	}
+	if !BatchInverseFr([]*Fr{new(Fr)})[0].IsZero() {
+		t.Errorf("Batch inversion of a lone zero should be zero")
+	}
+}
+
+func TestBatchNormalize(t *testing.T) {
+	g1 := G1Generator()
+	g2 := G2Generator()
+
+	var j1 []*G1Jac
+	var j2 []*G2Jac
+	acc1 := new(G1Jac).FromAffine(g1)
+	acc2 := new(G2Jac).FromAffine(g2)
+	for i := 0; i < 10; i++ {
+		if i == 3 {
+			j1 = append(j1, new(G1Jac).FromAffine(&G1{}))
+			j2 = append(j2, new(G2Jac).FromAffine(&G2{}))
+			continue
+		}
+		j1 = append(j1, acc1)
+		j2 = append(j2, acc2)
+		acc1 = acc1.Double()
+		acc2 = acc2.Double()
+	}
+
+	a1 := BatchNormalizeG1(j1)
+	a2 := BatchNormalizeG2(j2)
+	for i := range j1 {
+		if !a1[i].Equal(j1[i].ToAffine()) {
+			t.Errorf("BatchNormalizeG1 mismatch at %d", i)
+		}
+		if !a2[i].Equal(j2[i].ToAffine()) {
+			t.Errorf("BatchNormalizeG2 mismatch at %d", i)
+		}
+	}
+	if !a1[3].IsInfinity() || !a2[3].IsInfinity() {
+		t.Errorf("BatchNormalize should keep the point at infinity")
+	}
+}
+
+// ============================================================================
+// Fp6 / Fp12 Tests
+// ============================================================================
+
+// randomFp2 returns a uniformly random Fp2 element
+func randomFp2(t testing.TB) *Fp2 {
+	a, err := rand.Int(rand.Reader, P)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := rand.Int(rand.Reader, P)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewFp2(a, b)
+}
+
+// randomFp12 returns a uniformly random Fp12 element
+func randomFp12(t testing.TB) *Fp12 {
+	return NewFp12(
+		NewFp6(randomFp2(t), randomFp2(t), randomFp2(t)),
+		NewFp6(randomFp2(t), randomFp2(t), randomFp2(t)),
+	)
+}
+
+func TestFp6SquareAndInverse(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		a := NewFp6(randomFp2(t), randomFp2(t), randomFp2(t))
+
+		sq := a.Square()
+		if *sq != *a.Mul(a) {
+			t.Errorf("Fp6 square does not match a * a")
+		}
+
+		one := a.Mul(a.Inverse())
+		if *one != *fp12One().c0.Copy() {
+			t.Errorf("Fp6 inverse failed: a * a^(-1) should equal 1")
+		}
+	}
+}
+
+func TestFp12SquareAndInverse(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		a := randomFp12(t)
+
+		if *a.Square() != *a.Mul(a) {
+			t.Errorf("Fp12 square does not match a * a")
+		}
+
+		if !a.Mul(a.Inverse()).IsOne() {
+			t.Errorf("Fp12 inverse failed: a * a^(-1) should equal 1")
+		}
+	}
+}
+
+func TestSparseMultiplication(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		c0, c1, c4 := randomFp2(t), randomFp2(t), randomFp2(t)
+
+		x := NewFp6(randomFp2(t), randomFp2(t), randomFp2(t))
+		var z Fp6
+		fp6MulBy01(&z, x, c0, c1)
+		if z != *x.Mul(NewFp6(c0, c1, new(Fp2))) {
+			t.Errorf("fp6MulBy01 does not match Fp6 multiplication")
+		}
+
+		a := randomFp12(t)
+		sparse := NewFp12(NewFp6(c0, new(Fp2), new(Fp2)), NewFp6(c1, c4, new(Fp2)))
+		if *a.MulBy034(c0, c1, c4) != *a.Mul(sparse) {
+			t.Errorf("MulBy034 does not match Fp12 multiplication")
+		}
+	}
+
+	// Halving is the inverse of doubling, for odd and even representatives
+	for _, v := range []int64{1, 2, 3, 12345} {
+		var x, h Fp
+		fpSetBig(&x, big.NewInt(v))
+		fpHalve(&h, &x)
+		if fpDouble(&h, &h); h != x {
+			t.Errorf("fpHalve(%d) doubled should give %d", v, v)
+		}
+	}
+}
+
+// randomCyclotomic returns a random element of the cyclotomic subgroup,
+// f^((p⁶-1)(p²+1)) for a random f
+func randomCyclotomic(t testing.TB) *Fp12 {
+	f := randomFp12(t)
+	var c, inv Fp12
+	fp12Conjugate(&c, f)
+	fp12Inverse(&inv, f)
+	fp12Mul(&c, &c, &inv)
+	var cp2 Fp12
+	fp12Frobenius(&cp2, &c, 2)
+	fp12Mul(&c, &c, &cp2)
+	return &c
+}
+
+func TestCyclotomicSquare(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		a := randomCyclotomic(t)
+		want := a.Square()
+
+		var z Fp12
+		fp12CyclotomicSquare(&z, a)
+		if z != *want {
+			t.Errorf("Granger-Scott squaring does not match Fp12.Square")
+		}
+
+		// Karabina: squaring in compressed form, then decompressing
+		fp12CyclotomicSquareCompressed(&z, a)
+		fp12DecompressKarabina(&z)
+		if z != *want {
+			t.Errorf("Karabina squaring does not match Fp12.Square")
+		}
+	}
+
+	// Several compressed squarings, decompressed together
+	a := randomCyclotomic(t)
+	want := a.Copy()
+	x := *a
+	var batch []*Fp12
+	for i := 0; i < 5; i++ {
+		want = want.Square()
+		fp12CyclotomicSquareCompressed(&x, &x)
+		c := x
+		batch = append(batch, &c)
+	}
+	batch = append(batch, fp12One())
+	fp12BatchDecompressKarabina(batch)
+	if *batch[4] != *want {
+		t.Errorf("Batch Karabina decompression does not match repeated Fp12.Square")
+	}
+	if !batch[5].IsOne() {
+		t.Errorf("Batch Karabina decompression of 1 should give 1")
+	}
+}
+
+func TestCyclotomicExp(t *testing.T) {
+	a := randomCyclotomic(t)
+	for _, e := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(12345), curveU, Order} {
+		if *cyclotomicExp(a, e) != *a.Exp(e) {
+			t.Errorf("cyclotomicExp(a, %s) does not match Fp12.Exp", e)
+		}
+	}
+}
+
+func TestFrobeniusCoefficients(t *testing.T) {
+	xi := NewFp2(big.NewInt(9), big.NewInt(1))
+	pi := big.NewInt(1)
+	for i := 1; i <= 3; i++ {
+		pi.Mul(pi, P)
+		for k := 1; k <= 5; k++ {
+			// γ(i, k) = ξ^(k(p^i - 1)/6)
+			e := new(big.Int).Sub(pi, big.NewInt(1))
+			e.Mul(e, big.NewInt(int64(k)))
+			e.Div(e, big.NewInt(6))
+			var want Fp2
+			want.a = fpOne
+			for j := e.BitLen() - 1; j >= 0; j-- {
+				fp2Square(&want, &want)
+				if e.Bit(j) == 1 {
+					fp2Mul(&want, &want, xi)
+				}
+			}
+			if !frobeniusCoeffs[i-1][k-1].Equal(&want) {
+				t.Errorf("frobeniusCoeffs[%d][%d] should be ξ^(%d(p^%d-1)/6)", i-1, k-1, k, i)
+			}
+		}
+	}
+}
+
+func TestFp12Frobenius(t *testing.T) {
+	for n := 0; n < 3; n++ {
+		a := randomFp12(t)
+
+		if *a.Frobenius(1) != *a.Exp(P) {
+			t.Errorf("Frobenius(1) should equal a^p")
+		}
+
+		// Frobenius(k) = a^(p^k) for every k up to the order of the map
+		want := a.Copy()
+		for k := 0; k <= 12; k++ {
+			if *a.Frobenius(k) != *want {
+				t.Errorf("Frobenius(%d) should equal Frobenius(1) applied %d times", k, k)
+			}
+			want = want.Frobenius(1)
+		}
+
+		var conj Fp12
+		fp12Conjugate(&conj, a)
+		if *a.Frobenius(6) != conj {
+			t.Errorf("Frobenius(6) should be the conjugation over Fp6")
+		}
+		if *a.Frobenius(-1).Frobenius(1) != *a || *a.Frobenius(25) != *a.Frobenius(1) {
+			t.Errorf("Frobenius should reduce its power mod 12")
+		}
+	}
+}
+
 // ============================================================================
 // G1 Tests
 // ============================================================================
@@ -232,7 +863,7 @@ func TestG1Serialization(t *testing.T) {
 }
 
 func TestG1InfinityMarshal(t *testing.T) {
-	inf := &G1{X: big.NewInt(0), Y: big.NewInt(0)}
+	inf := &G1{}
 	buf := inf.Marshal()
 
 	g2, err := UnmarshalG1(buf)
@@ -261,6 +892,138 @@ func TestG1DistributiveLaw(t *testing.T) {
 	}
 }
 
+func TestGLVConstants(t *testing.T) {
+	one := NewFp(big.NewInt(1))
+	if glvBeta.Equal(one) || !glvBeta.Square().Mul(glvBeta).Equal(one) {
+		t.Errorf("β should be a primitive cube root of unity in Fp")
+	}
+
+	lambda3 := new(big.Int).Exp(glvLambda, big.NewInt(3), Order)
+	if lambda3.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("λ should be a cube root of unity mod Order")
+	}
+
+	// φ(g) = (βx, y) must equal λ·g
+	g := G1Generator()
+	phi := &G1{X: *g.X.Mul(glvBeta), Y: g.Y}
+	if !g1ScalarMultBinary(g, glvLambda).Equal(phi) {
+		t.Errorf("φ(g) should equal λ·g")
+	}
+
+	// Both basis vectors lie in the lattice a + bλ ≡ 0 mod Order
+	b1 := new(big.Int).Neg(glvB1Neg)
+	for _, v := range [][2]*big.Int{{glvA1, b1}, {glvA2, glvB2}} {
+		x := new(big.Int).Mul(v[1], glvLambda)
+		x.Add(x, v[0]).Mod(x, Order)
+		if x.Sign() != 0 {
+			t.Errorf("GLV basis vector (%s, %s) is not in the lattice", v[0], v[1])
+		}
+	}
+}
+
+func TestGLVDecompose(t *testing.T) {
+	bound := new(big.Int).Lsh(big.NewInt(1), 128)
+	for i := 0; i < 200; i++ {
+		k, _ := rand.Int(rand.Reader, Order)
+		k1, k2 := glvDecompose(k)
+
+		// k1 + k2·λ ≡ k mod Order
+		sum := new(big.Int).Mul(k2, glvLambda)
+		sum.Add(sum, k1).Sub(sum, k).Mod(sum, Order)
+		if sum.Sign() != 0 {
+			t.Fatalf("GLV decomposition of %s does not recombine", k)
+		}
+		if new(big.Int).Abs(k1).Cmp(bound) >= 0 || new(big.Int).Abs(k2).Cmp(bound) >= 0 {
+			t.Errorf("GLV halves of %s exceed 128 bits: %s, %s", k, k1, k2)
+		}
+	}
+}
+
+func TestG1ScalarMultGLVMatchesBinary(t *testing.T) {
+	g := G1Generator()
+	p := g.ScalarMult(big.NewInt(31337))
+
+	rMinus1 := new(big.Int).Sub(Order, big.NewInt(1))
+	scalars := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		rMinus1,
+		new(big.Int).Set(Order),
+		new(big.Int).Add(Order, big.NewInt(1)),
+		new(big.Int).Lsh(Order, 3),
+		new(big.Int).Lsh(big.NewInt(1), 300),
+		big.NewInt(-1),
+		new(big.Int).Neg(rMinus1),
+		new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255)),
+		glvLambda,
+		new(big.Int).Neg(glvLambda),
+	}
+	for i := 0; i < 50; i++ {
+		k, _ := rand.Int(rand.Reader, Order)
+		scalars = append(scalars, k)
+	}
+
+	for _, k := range scalars {
+		for _, base := range []*G1{g, p} {
+			want := g1ScalarMultBinary(base, k)
+			if got := base.ScalarMult(k); !got.Equal(want) {
+				t.Errorf("GLV ScalarMult disagrees with double-and-add for k = %s", k)
+			}
+		}
+		if !ScalarBaseMult(k).Equal(g1ScalarMultBinary(g, k)) {
+			t.Errorf("ScalarBaseMult disagrees with double-and-add for k = %s", k)
+		}
+	}
+
+	if !(&G1{}).ScalarMult(big.NewInt(5)).IsInfinity() {
+		t.Errorf("k·infinity should be infinity")
+	}
+}
+
+func TestG1JacobianMatchesAffine(t *testing.T) {
+	g := G1Generator()
+	p := g.ScalarMult(big.NewInt(5))
+	q := g.ScalarMult(big.NewInt(9))
+
+	// Give pj a non-trivial Z so the formulas see a real Jacobian input
+	pj := new(G1Jac).FromAffine(g).Add(new(G1Jac).FromAffine(g.ScalarMult(big.NewInt(4))))
+	qj := new(G1Jac).FromAffine(q)
+
+	if !pj.ToAffine().Equal(p) {
+		t.Fatalf("FromAffine/ToAffine round trip failed")
+	}
+	if !pj.Add(qj).ToAffine().Equal(p.Add(q)) {
+		t.Errorf("Jacobian add disagrees with affine add")
+	}
+	if !pj.Double().ToAffine().Equal(p.Double()) {
+		t.Errorf("Jacobian double disagrees with affine double")
+	}
+
+	var r G1Jac
+	g1JacAddMixed(&r, pj, q)
+	if !r.ToAffine().Equal(p.Add(q)) {
+		t.Errorf("Jacobian mixed add disagrees with affine add")
+	}
+
+	// p + p and p + (-p) take the special cases
+	if !pj.Add(new(G1Jac).FromAffine(p)).ToAffine().Equal(p.Double()) {
+		t.Errorf("Jacobian p + p should equal 2p")
+	}
+	g1JacAddMixed(&r, pj, p.Neg())
+	if !r.IsInfinity() {
+		t.Errorf("Jacobian p + (-p) should be infinity")
+	}
+
+	inf := new(G1Jac).FromAffine(&G1{})
+	if !inf.IsInfinity() || !inf.ToAffine().IsInfinity() {
+		t.Errorf("Jacobian infinity should map to affine infinity")
+	}
+	if !inf.Add(pj).ToAffine().Equal(p) || !pj.Add(inf).ToAffine().Equal(p) {
+		t.Errorf("Jacobian infinity should be the identity")
+	}
+}
+
 // ============================================================================
 // G2 Tests
 // ============================================================================
@@ -337,6 +1100,241 @@ func TestG2Negation(t *testing.T) {
 	}
 }
 
+func TestG2Psi(t *testing.T) {
+	g := G2Generator()
+	p := g.ScalarMult(big.NewInt(31337))
+
+	// λ = p mod Order is a root of λ⁴ - λ² + 1 mod Order
+	l2 := new(big.Int).Mul(glsLambda, glsLambda)
+	poly := new(big.Int).Mul(l2, l2)
+	poly.Sub(poly, l2).Add(poly, big.NewInt(1)).Mod(poly, Order)
+	if poly.Sign() != 0 || new(big.Int).Mod(P, Order).Cmp(glsLambda) != 0 {
+		t.Errorf("glsLambda should be p mod Order and satisfy λ⁴ - λ² + 1 = 0")
+	}
+
+	for _, q := range []*G2{g, p} {
+		psi := q.Psi()
+		if !psi.IsOnCurve() {
+			t.Errorf("ψ(q) should lie on the twist")
+		}
+		if !psi.Equal(g2ScalarMultBinary(q, glsLambda)) {
+			t.Errorf("ψ(q) should equal λ·q")
+		}
+		var j G2Jac
+		g2JacPsi(&j, new(G2Jac).FromAffine(q).Double())
+		if !j.ToAffine().Equal(q.Double().Psi()) {
+			t.Errorf("Jacobian ψ disagrees with affine ψ")
+		}
+	}
+	if !(&G2{}).Psi().IsInfinity() {
+		t.Errorf("ψ(infinity) should be infinity")
+	}
+}
+
+func TestGLSDecompose(t *testing.T) {
+	for i, b := range glsBasis {
+		x := new(big.Int)
+		for j := len(b) - 1; j >= 0; j-- {
+			x.Mul(x, glsLambda).Add(x, b[j])
+		}
+		if x.Mod(x, Order).Sign() != 0 {
+			t.Errorf("GLS basis vector %d is not in the lattice", i)
+		}
+	}
+
+	bound := new(big.Int).Lsh(big.NewInt(1), 65)
+	for i := 0; i < 200; i++ {
+		k, _ := rand.Int(rand.Reader, Order)
+		ks := glsDecompose(k)
+
+		sum := new(big.Int)
+		for j := len(ks) - 1; j >= 0; j-- {
+			sum.Mul(sum, glsLambda).Add(sum, ks[j])
+			if new(big.Int).Abs(ks[j]).Cmp(bound) >= 0 {
+				t.Errorf("GLS piece %d of %s exceeds 65 bits: %s", j, k, ks[j])
+			}
+		}
+		if sum.Sub(sum, k).Mod(sum, Order).Sign() != 0 {
+			t.Fatalf("GLS decomposition of %s does not recombine", k)
+		}
+	}
+}
+
+func TestG2ScalarMultGLSMatchesBinary(t *testing.T) {
+	g := G2Generator()
+	p := g2ScalarMultBinary(g, big.NewInt(31337))
+
+	rMinus1 := new(big.Int).Sub(Order, big.NewInt(1))
+	scalars := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		rMinus1,
+		new(big.Int).Set(Order),
+		new(big.Int).Add(Order, big.NewInt(1)),
+		new(big.Int).Lsh(big.NewInt(1), 300),
+		big.NewInt(-1),
+		new(big.Int).Neg(rMinus1),
+		glsLambda,
+		new(big.Int).Neg(glsLambda),
+		new(big.Int).Set(curveU),
+	}
+	for i := 0; i < 30; i++ {
+		k, _ := rand.Int(rand.Reader, Order)
+		scalars = append(scalars, k)
+	}
+
+	for _, k := range scalars {
+		want := new(big.Int).Mod(k, Order)
+		for _, base := range []*G2{g, p} {
+			if got := base.ScalarMult(k); !got.Equal(g2ScalarMultBinary(base, want)) {
+				t.Errorf("GLS ScalarMult disagrees with double-and-add for k = %s", k)
+			}
+		}
+	}
+
+	if !(&G2{}).ScalarMult(big.NewInt(5)).IsInfinity() {
+		t.Errorf("k·infinity should be infinity")
+	}
+}
+
+func TestG2JacobianMatchesAffine(t *testing.T) {
+	g := G2Generator()
+	p := g.ScalarMult(big.NewInt(5))
+	q := g.ScalarMult(big.NewInt(9))
+
+	// Give pj a non-trivial Z so the formulas see a real Jacobian input
+	pj := new(G2Jac).FromAffine(g).Add(new(G2Jac).FromAffine(g.ScalarMult(big.NewInt(4))))
+	qj := new(G2Jac).FromAffine(q)
+
+	if !pj.ToAffine().Equal(p) {
+		t.Fatalf("FromAffine/ToAffine round trip failed")
+	}
+	if !pj.Add(qj).ToAffine().Equal(p.Add(q)) {
+		t.Errorf("Jacobian add disagrees with affine add")
+	}
+	if !pj.Double().ToAffine().Equal(p.Double()) {
+		t.Errorf("Jacobian double disagrees with affine double")
+	}
+
+	var r G2Jac
+	g2JacAddMixed(&r, pj, q)
+	if !r.ToAffine().Equal(p.Add(q)) {
+		t.Errorf("Jacobian mixed add disagrees with affine add")
+	}
+
+	// p + p and p + (-p) take the special cases
+	if !pj.Add(new(G2Jac).FromAffine(p)).ToAffine().Equal(p.Double()) {
+		t.Errorf("Jacobian p + p should equal 2p")
+	}
+	g2JacAddMixed(&r, pj, p.Neg())
+	if !r.IsInfinity() {
+		t.Errorf("Jacobian p + (-p) should be infinity")
+	}
+
+	inf := new(G2Jac).FromAffine(&G2{})
+	if !inf.IsInfinity() || !inf.ToAffine().IsInfinity() {
+		t.Errorf("Jacobian infinity should map to affine infinity")
+	}
+	if !inf.Add(pj).ToAffine().Equal(p) || !pj.Add(inf).ToAffine().Equal(p) {
+		t.Errorf("Jacobian infinity should be the identity")
+	}
+}
+
+// ============================================================================
+// Fixed-Base Tests
+// ============================================================================
+
+// fixedBaseScalars returns scalars that exercise the signed window recoding:
+// digits at the 128 boundary, carries through runs of 0xff and values at
+// or beyond Order
+func fixedBaseScalars(t *testing.T) []*big.Int {
+	scalars := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(128),
+		big.NewInt(129),
+		big.NewInt(255),
+		big.NewInt(256),
+		big.NewInt(-3),
+		fromHex("80808080808080808080808080808080808080808080808080808080808080"),
+		fromHex("2fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		new(big.Int).Sub(Order, big.NewInt(1)),
+		Order,
+		new(big.Int).Lsh(Order, 3),
+	}
+	for i := 0; i < 10; i++ {
+		k, err := randomScalar(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scalars = append(scalars, k)
+	}
+	return scalars
+}
+
+func TestFixedBaseG1(t *testing.T) {
+	base := G1Generator().ScalarMult(big.NewInt(987654321))
+	table := NewFixedBaseG1(base)
+
+	for _, k := range fixedBaseScalars(t) {
+		if !table.ScalarMult(k).Equal(base.ScalarMult(k)) {
+			t.Errorf("FixedBaseG1 disagrees with ScalarMult for k = %s", k)
+		}
+		if !ScalarBaseMult(k).Equal(G1Generator().ScalarMult(k)) {
+			t.Errorf("ScalarBaseMult disagrees with ScalarMult for k = %s", k)
+		}
+	}
+
+	k := NewFr(big.NewInt(42))
+	if !table.ScalarMultFr(k).Equal(base.ScalarMultFr(k)) {
+		t.Errorf("FixedBaseG1.ScalarMultFr disagrees with ScalarMultFr")
+	}
+	if !NewFixedBaseG1(&G1{}).ScalarMult(big.NewInt(5)).IsInfinity() {
+		t.Errorf("Fixed-base multiple of infinity should be infinity")
+	}
+}
+
+func TestFixedBaseG2(t *testing.T) {
+	base := G2Generator().ScalarMult(big.NewInt(987654321))
+	table := NewFixedBaseG2(base)
+
+	for _, k := range fixedBaseScalars(t) {
+		if !table.ScalarMult(k).Equal(base.ScalarMult(k)) {
+			t.Errorf("FixedBaseG2 disagrees with ScalarMult for k = %s", k)
+		}
+		if !ScalarBaseMultG2(k).Equal(G2Generator().ScalarMult(k)) {
+			t.Errorf("ScalarBaseMultG2 disagrees with ScalarMult for k = %s", k)
+		}
+	}
+
+	k := NewFr(big.NewInt(42))
+	if !table.ScalarMultFr(k).Equal(base.ScalarMultFr(k)) {
+		t.Errorf("FixedBaseG2.ScalarMultFr disagrees with ScalarMultFr")
+	}
+	if !NewFixedBaseG2(&G2{}).ScalarMult(big.NewInt(5)).IsInfinity() {
+		t.Errorf("Fixed-base multiple of infinity should be infinity")
+	}
+}
+
+func TestFixedBaseDigits(t *testing.T) {
+	for _, k := range fixedBaseScalars(t) {
+		want := new(big.Int).Mod(k, Order)
+		sum := new(big.Int)
+		digits := fixedBaseDigitsOf(k)
+		for i := len(digits) - 1; i >= 0; i-- {
+			if digits[i] < -fixedBaseDigits || digits[i] > fixedBaseDigits {
+				t.Fatalf("Digit %d of %s is %d", i, k, digits[i])
+			}
+			sum.Lsh(sum, fixedBaseWindow)
+			sum.Add(sum, big.NewInt(int64(digits[i])))
+		}
+		if sum.Cmp(want) != 0 {
+			t.Errorf("Digits of %s evaluate to %s", k, sum)
+		}
+	}
+}
+
 // ============================================================================
 // Pairing Tests
 // ============================================================================
@@ -364,6 +1362,74 @@ func TestPairingBilinearity(t *testing.T) {
 	}
 }
 
+// TestPairingKnownAnswer checks Pair against values computed with
+// go-ethereum's crypto/bn256/cloudflare. Its GT encoding lists the twelve
+// Fp coefficients in the reverse of GT.Marshal's order.
+func TestPairingKnownAnswer(t *testing.T) {
+	a, _ := new(big.Int).SetString("1234567890123456789012345678901234567890", 10)
+	b, _ := new(big.Int).SetString("9876543210987654321098765432109876543210", 10)
+
+	tests := []struct {
+		name string
+		p    *G1
+		q    *G2
+		want string
+	}{
+		{
+			name: "e(G1, G2)",
+			p:    G1Generator(),
+			q:    G2Generator(),
+			want: "108c19d15f9446f744d0f110405d3856d6cc3bda6c4d537663729f52576284170dc26f240656bbe2029bd441d77c221f0ba4c70c94b29b5f17f0f6d08745a069" +
+				"279db296f9d479292532c7c493d8e0722b6efae42158387564889c79fc038ee31ad9db1937fd72f4ac462173d31d3d6117411fa48dba8d499d762b47edb3b54a" +
+				"27ed208e7a0b55ae6e710bbfbd2fd922669c026360e37cc5b2ab8624115361042c53748bcd21a7c038fb30ddc8ac3bf0af25d7859cfbc12c30c866276c565909" +
+				"2b03614464f04dd772d86df88674c270ffc8747ea13e72da95e3594468f222c401676555de427abc409c4a394bc5426886302996919d4bf4bdd02236e14b3636" +
+				"2067586885c3318eeffa1938c754fe3c60224ee5ae15e66af6b5104c47c8c5d80e841c2ac18a4003ac9326b9558380e0bc27fdd375e3605f96b819a358d34bde" +
+				"084f330485b09e866bc2f2ea2b897394deaf3f12aa31f28cb0552990967d470412c70e90e12b7874510cd1707e8856f71bf7f61d72631e268fca81000db9a1f5",
+		},
+		{
+			name: "e([a]G1, [b]G2)",
+			p:    G1Generator().ScalarMult(a),
+			q:    G2Generator().ScalarMult(b),
+			want: "0960fd541dc46e32752479af7220f18d21b1086d8a643ebb6868a68e88a64aaa0a77a6fb803ed1510b168650a7284d0b6c68bd9f6bd8aed61ff3c0b0526097be" +
+				"0f7a6321b6a905b5799eae61d74d9bb5b830a51ebfe8189b9e706642f982a22c1b947c5a3475d732768c29b92ae7e79190f27c0768fae46ce560092cc570d0ea" +
+				"29a38ba1679da1e2c46be51cca2b1dcf161deaa6e54aa375277acd248a58bcf012e72d4e2318140570a1ac3d57ba8ea47635ad1ea1ca71ef9caea36e21fe5f41" +
+				"0a1c1b870aff88152abfd7971492b000f2095a3e9bfeb73509d2074407dad22f247a1438e12c06b72af7d02e3fe156fe50b0756ae9a408d5b78d64222b74446e" +
+				"0025fc101bfba2a9c5d31e939130ef29fd1223208b6ac7e1d735ccb959bcbf2d2a13247e3c2634bb906181d897acd72452db6897d5d2f1af023fe70a7e703851" +
+				"1e7252943c477a8d207bb07640749581e42f2e8546ffed0fdf2b0329c793a6751af060269dd8b5785d8c59e49d9137515a27ab358a3738ce513c16cdc3b75717",
+		},
+	}
+
+	for _, tt := range tests {
+		enc := Pair(tt.p, tt.q).Marshal()
+		rev := make([]byte, 0, len(enc))
+		for i := len(enc); i > 0; i -= 32 {
+			rev = append(rev, enc[i-32:i]...)
+		}
+		if got := hex.EncodeToString(rev); got != tt.want {
+			t.Errorf("%s mismatch:\n got %s\nwant %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestSixUPlus2NAF checks the signed-digit loop parameter of the Miller loop
+func TestSixUPlus2NAF(t *testing.T) {
+	sum := new(big.Int)
+	for i := len(sixUPlus2NAF) - 1; i >= 0; i-- {
+		d := sixUPlus2NAF[i]
+		if d < -1 || d > 1 {
+			t.Fatalf("Digit %d is %d", i, d)
+		}
+		if d != 0 && i > 0 && sixUPlus2NAF[i-1] != 0 {
+			t.Errorf("Digits %d and %d are both non-zero", i, i-1)
+		}
+		sum.Lsh(sum, 1)
+		sum.Add(sum, big.NewInt(int64(d)))
+	}
+	if sum.Cmp(sixUPlus2) != 0 {
+		t.Errorf("NAF evaluates to %s, expected %s", sum, sixUPlus2)
+	}
+}
+
 func TestPairingNonDegenerate(t *testing.T) {
 	g1 := G1Generator()
 	g2 := G2Generator()
@@ -379,11 +1445,8 @@ func TestPairingNonDegenerate(t *testing.T) {
 func TestPairingWithInfinity(t *testing.T) {
 	g1 := G1Generator()
 	g2 := G2Generator()
-	inf1 := &G1{X: big.NewInt(0), Y: big.NewInt(0)}
-	inf2 := &G2{
-		X: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-		Y: &Fp2{a: big.NewInt(0), b: big.NewInt(0)},
-	}
+	inf1 := &G1{}
+	inf2 := &G2{}
 
 	// e(inf, g2) should be 1
 	result1 := Pair(inf1, g2)
@@ -438,6 +1501,221 @@ func TestPairingCheckMultiple(t *testing.T) {
 	_ = PairingCheck(pairs)
 }
 
+func TestPairingCheckPairs(t *testing.T) {
+	g1 := G1Generator()
+	g2 := G2Generator()
+	a := big.NewInt(7)
+
+	// e(a*g1, g2) * e(-g1, a*g2) = 1
+	ok, err := PairingCheckPairs(
+		[]*G1{g1.ScalarMult(a), g1.Neg()},
+		[]*G2{g2, g2.ScalarMult(a)},
+	)
+	if !ok || err != nil {
+		t.Errorf("Valid pairing check failed: %v", err)
+	}
+
+	// e(g1, g2) * e(g1, g2) != 1
+	ok, err = PairingCheckPairs([]*G1{g1, g1}, []*G2{g2, g2})
+	if ok || err != ErrInvalidPairing {
+		t.Errorf("Expected ErrInvalidPairing, got %v, %v", ok, err)
+	}
+
+	// Empty input and pairs with infinity pass
+	if ok, err := PairingCheckPairs(nil, nil); !ok || err != nil {
+		t.Errorf("Empty pairing check should pass, got %v, %v", ok, err)
+	}
+	if ok, err := PairingCheckPairs([]*G1{{}}, []*G2{g2}); !ok || err != nil {
+		t.Errorf("Pairing check with infinity should pass, got %v, %v", ok, err)
+	}
+
+	if _, err := PairingCheckPairs([]*G1{g1}, nil); err != ErrLengthMismatch {
+		t.Errorf("Expected ErrLengthMismatch, got %v", err)
+	}
+}
+
+func TestMultiMillerLoop(t *testing.T) {
+	g1 := G1Generator()
+	g2 := G2Generator()
+	g1s := []*G1{g1, g1.ScalarMult(big.NewInt(5)), {}, g1.ScalarMult(big.NewInt(11))}
+	g2s := []*G2{g2.ScalarMult(big.NewInt(3)), g2, g2, g2.ScalarMult(big.NewInt(2))}
+
+	// Sharing the squarings gives exactly the product of separate loops
+	want := fp12One()
+	for i := range g1s {
+		fp12Mul(want, want, millerLoop(g1s[i], g2s[i]))
+	}
+	ml, err := MillerLoop(g1s, g2s)
+	if err != nil {
+		t.Fatalf("MillerLoop failed: %v", err)
+	}
+	if *ml.value != *want {
+		t.Errorf("MillerLoop should equal the product of the individual Miller loops")
+	}
+
+	// e(g1, g2)^(3 + 5 + 0 + 22)
+	got := FinalExponentiate(ml)
+	if *got.value != *Pair(g1, g2).value.Exp(big.NewInt(30)) {
+		t.Errorf("FinalExponentiate(MillerLoop) should equal the product of pairings")
+	}
+
+	if empty, err := MillerLoop(nil, nil); err != nil || !FinalExponentiate(empty).IsOne() {
+		t.Errorf("Empty MillerLoop should give 1, got error %v", err)
+	}
+	if _, err := MillerLoop(g1s, g2s[:2]); err != ErrLengthMismatch {
+		t.Errorf("Expected ErrLengthMismatch, got %v", err)
+	}
+}
+
+func TestFinalExponentiationMatchesExp(t *testing.T) {
+	// (p¹² - 1) / Order
+	e := new(big.Int).Exp(P, big.NewInt(12), nil)
+	e.Sub(e, big.NewInt(1))
+	e.Div(e, Order)
+
+	inputs := []*Fp12{randomFp12(t), randomFp12(t), millerLoop(G1Generator(), G2Generator())}
+	for _, f := range inputs {
+		want := f.Exp(e)
+		if got := FinalExponentiate(&GT{value: f}); *got.value != *want {
+			t.Errorf("FinalExponentiate should equal f^((p¹²-1)/r)")
+		}
+	}
+
+	// The result lies in GT: its r-th power is one
+	f := finalExponentiation(randomFp12(t))
+	if !f.Exp(Order).IsOne() {
+		t.Errorf("finalExponentiation output should have order dividing r")
+	}
+}
+
+func TestPreparedG2(t *testing.T) {
+	g1 := G1Generator()
+	g2 := G2Generator()
+	p := g1.ScalarMult(big.NewInt(9))
+	q := g2.ScalarMult(big.NewInt(4))
+
+	pq := PrepareG2(q)
+	if !PairPrepared(p, pq).Equal(Pair(p, q)) {
+		t.Errorf("PairPrepared should match Pair")
+	}
+
+	// Adding -r to r gives the vertical line through r and the identity
+	r := g2Proj{X: q.X, Y: q.Y}
+	r.Z.a = fpOne
+	if l := lineFunctionAdd(&r, q.Neg()); !l.c.IsZero() || l.b.IsZero() || !r.Z.IsZero() {
+		t.Errorf("Adding opposite points should give a vertical line and infinity")
+	}
+	if !PairPrepared(p, PrepareG2(&G2{})).IsOne() || !PairPrepared(&G1{}, pq).IsOne() {
+		t.Errorf("PairPrepared with infinity should be identity")
+	}
+
+	// e(9·g1, 4·g2) · e(-6·g1, 6·g2) = 1, with a prepared point reused
+	pg2 := PrepareG2(g2.ScalarMult(big.NewInt(6)))
+	ok, err := PairingCheckPrepared([]*G1{p, g1.ScalarMult(big.NewInt(6)).Neg()}, []*PreparedG2{pq, pg2})
+	if !ok || err != nil {
+		t.Errorf("Valid prepared pairing check failed: %v", err)
+	}
+	ok, err = PairingCheckPrepared([]*G1{p, g1}, []*PreparedG2{pq, pg2})
+	if ok || err != ErrInvalidPairing {
+		t.Errorf("Expected ErrInvalidPairing, got %v, %v", ok, err)
+	}
+
+	var ie *PairingInputError
+	offCurve := &G1{X: *NewFp(big.NewInt(1)), Y: *NewFp(big.NewInt(1))}
+	if _, err := PairingCheckPrepared([]*G1{g1, offCurve}, []*PreparedG2{pq, pq}); !errors.As(err, &ie) || ie.Index != 1 {
+		t.Errorf("Expected a PairingInputError for pair 1, got %v", err)
+	}
+	if _, err := PairingCheckPrepared([]*G1{g1}, nil); err != ErrLengthMismatch {
+		t.Errorf("Expected ErrLengthMismatch, got %v", err)
+	}
+}
+
+// affineLine returns the line with slope lambda through the untwisted image
+// of (x, y), evaluated at p: yp - λw·xp + (λx - y)w³
+func affineLine(lambda, x, y *Fp2, p *G1) *Fp12 {
+	var yp, xp Fp2
+	yp.a = p.Y
+	xp.a = p.X
+	c3 := lambda.Mul(&xp).Neg()
+	c4 := lambda.Mul(x).Sub(y)
+	return NewFp12(NewFp6(&yp, new(Fp2), new(Fp2)), NewFp6(c3, c4, new(Fp2)))
+}
+
+func TestLineFunctions(t *testing.T) {
+	q := G2Generator().ScalarMult(big.NewInt(5))
+	p := G1Generator().ScalarMult(big.NewInt(3))
+	three := NewFp2(big.NewInt(3), new(big.Int))
+	two := NewFp2(big.NewInt(2), new(big.Int))
+
+	// The projective lines are the affine ones scaled by c, an Fp2 factor,
+	// with yp, xp and the constant on w⁰, w¹ and w³
+	r := g2Proj{X: q.X, Y: q.Y}
+	r.Z.a = fpOne
+	l := lineFunctionDouble(&r)
+	lambda := three.Mul(q.X.Square()).Mul(two.Mul(&q.Y).Inverse())
+	want := affineLine(lambda, &q.X, &q.Y, p)
+	got := new(Fp12)
+	fp12MulByLine(got, fp12One(), &l, p)
+	if *got != *want.MulBy034(&l.c, new(Fp2), new(Fp2)) {
+		t.Errorf("Tangent line does not match its affine evaluation")
+	}
+
+	// r = 2q, now add q
+	rx := r.X.Mul(r.Z.Inverse())
+	ry := r.Y.Mul(r.Z.Inverse())
+	l = lineFunctionAdd(&r, q)
+	lambda = ry.Sub(&q.Y).Mul(rx.Sub(&q.X).Inverse())
+	want = affineLine(lambda, &q.X, &q.Y, p)
+	fp12MulByLine(got, fp12One(), &l, p)
+	if *got != *want.MulBy034(&l.c, new(Fp2), new(Fp2)) {
+		t.Errorf("Addition line does not match its affine evaluation")
+	}
+}
+
+func TestPairingCheckPairsInvalidInput(t *testing.T) {
+	g1 := G1Generator()
+	g2 := G2Generator()
+	offCurve := &G1{X: *NewFp(big.NewInt(1)), Y: *NewFp(big.NewInt(1))}
+	offSubgroup := twistPointOffSubgroup(t)
+
+	tests := []struct {
+		name  string
+		g1    []*G1
+		g2    []*G2
+		index int
+		group string
+		err   error
+	}{
+		{"G1 off curve", []*G1{g1, offCurve}, []*G2{g2, g2}, 1, "G1", ErrInvalidPoint},
+		{"nil G1", []*G1{nil}, []*G2{g2}, 0, "G1", ErrInvalidPoint},
+		{"G2 off curve", []*G1{g1}, []*G2{{X: g2.X, Y: g2.X}}, 0, "G2", ErrInvalidPoint},
+		{"G2 off subgroup", []*G1{g1, g1, g1}, []*G2{g2, g2, offSubgroup}, 2, "G2", ErrNotInSubgroup},
+	}
+
+	for _, tt := range tests {
+		ok, err := PairingCheckPairs(tt.g1, tt.g2)
+		if ok {
+			t.Errorf("%s: pairing check should fail", tt.name)
+		}
+		var ie *PairingInputError
+		if !errors.As(err, &ie) {
+			t.Errorf("%s: expected *PairingInputError, got %v", tt.name, err)
+			continue
+		}
+		if ie.Index != tt.index || ie.Group != tt.group || !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, expected %s element of pair %d with %v", tt.name, err, tt.group, tt.index, tt.err)
+		}
+	}
+
+	// The legacy wrapper reports malformed input as a failed check
+	if PairingCheck([][2]interface{}{{g1, offSubgroup}}) {
+		t.Errorf("PairingCheck should reject a G2 point outside the subgroup")
+	}
+	if PairingCheck([][2]interface{}{{g2, g1}}) {
+		t.Errorf("PairingCheck should reject swapped element types")
+	}
+}
+
 // ============================================================================
 // GT Tests
 // ============================================================================
@@ -478,6 +1756,296 @@ func TestGTSerialization(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// Canonical Encoding Tests
+// ============================================================================
+
+// putBig writes n as a 32-byte big-endian value at buf[off:]
+func putBig(buf []byte, off int, n *big.Int) {
+	n.FillBytes(buf[off : off+32])
+}
+
+func TestUnmarshalNonCanonical(t *testing.T) {
+	pMinus1 := new(big.Int).Sub(P, big.NewInt(1))
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	// Every coordinate of the generator shifted by p, plus p itself and the
+	// largest 256-bit value, must be rejected as non-canonical
+	g1 := G1Generator().Marshal()
+	for i := 0; i < 2; i++ {
+		for _, v := range []*big.Int{
+			new(big.Int).Add(new(big.Int).SetBytes(g1[32*i:32*i+32]), P),
+			P,
+			max,
+		} {
+			buf := append([]byte(nil), g1...)
+			putBig(buf, 32*i, v)
+			if _, err := UnmarshalG1(buf); err != ErrNonCanonical {
+				t.Errorf("UnmarshalG1 with coordinate %d = %x: expected ErrNonCanonical, got %v", i, v, err)
+			}
+		}
+	}
+
+	g2 := G2Generator().Marshal()
+	for i := 0; i < 4; i++ {
+		for _, v := range []*big.Int{
+			new(big.Int).Add(new(big.Int).SetBytes(g2[32*i:32*i+32]), P),
+			P,
+		} {
+			buf := append([]byte(nil), g2...)
+			putBig(buf, 32*i, v)
+			if _, err := UnmarshalG2(buf); err != ErrNonCanonical {
+				t.Errorf("UnmarshalG2 with coordinate %d = %x: expected ErrNonCanonical, got %v", i, v, err)
+			}
+		}
+	}
+
+	gt := Pair(G1Generator(), G2Generator()).Marshal()
+	for i := 0; i < 12; i++ {
+		buf := append([]byte(nil), gt...)
+		putBig(buf, 32*i, new(big.Int).Add(new(big.Int).SetBytes(gt[32*i:32*i+32]), P))
+		if _, err := UnmarshalGT(buf); err != ErrNonCanonical {
+			t.Errorf("UnmarshalGT with coefficient %d shifted by p: expected ErrNonCanonical, got %v", i, err)
+		}
+	}
+
+	// p - 1 is canonical, so it fails on the curve equation instead
+	buf := make([]byte, 64)
+	putBig(buf, 0, pMinus1)
+	putBig(buf, 32, pMinus1)
+	if _, err := UnmarshalG1(buf); err != ErrInvalidPoint {
+		t.Errorf("UnmarshalG1 of (p-1, p-1): expected ErrInvalidPoint, got %v", err)
+	}
+	buf = make([]byte, 384)
+	putBig(buf, 0, pMinus1)
+	if _, err := UnmarshalGT(buf); err != nil {
+		t.Errorf("UnmarshalGT should accept p - 1: %v", err)
+	}
+
+	// -g = (1, p - 2) has its largest coordinate close to p
+	neg := G1Generator().Neg()
+	if q, err := UnmarshalG1(neg.Marshal()); err != nil || !q.Equal(neg) {
+		t.Errorf("UnmarshalG1 of -g failed: %v", err)
+	}
+}
+
+func TestCompressedRoundTrip(t *testing.T) {
+	g1s := []*G1{G1Generator(), G1Generator().Neg(), {}}
+	g2s := []*G2{G2Generator(), G2Generator().Neg(), {}}
+	for i := 0; i < 20; i++ {
+		k, _ := randomScalar(rand.Reader)
+		g1s = append(g1s, ScalarBaseMult(k))
+		g2s = append(g2s, G2Generator().ScalarMult(k))
+	}
+
+	for _, p := range g1s {
+		buf := p.MarshalCompressed()
+		if len(buf) != 32 {
+			t.Fatalf("G1 compressed encoding should be 32 bytes, got %d", len(buf))
+		}
+		q, err := UnmarshalCompressedG1(buf)
+		if err != nil || !q.Equal(p) {
+			t.Errorf("G1 compressed round trip failed: %v", err)
+		}
+	}
+	for _, p := range g2s {
+		buf := p.MarshalCompressed()
+		if len(buf) != 64 {
+			t.Fatalf("G2 compressed encoding should be 64 bytes, got %d", len(buf))
+		}
+		q, err := UnmarshalCompressedG2(buf)
+		if err != nil || !q.Equal(p) {
+			t.Errorf("G2 compressed round trip failed: %v", err)
+		}
+	}
+
+	// p and -p share x and differ only in the sign flag
+	a, b := G1Generator().MarshalCompressed(), G1Generator().Neg().MarshalCompressed()
+	if a[0]^b[0] != compressedSign {
+		t.Errorf("G1 and -G1 should differ only in the sign flag")
+	}
+}
+
+func TestCompressedMalformed(t *testing.T) {
+	g1 := G1Generator().MarshalCompressed()
+	g2 := G2Generator().MarshalCompressed()
+
+	withByte := func(buf []byte, i int, b byte) []byte {
+		out := append([]byte(nil), buf...)
+		out[i] = b
+		return out
+	}
+	inf1 := (&G1{}).MarshalCompressed()
+	inf2 := (&G2{}).MarshalCompressed()
+
+	// x = 4 gives x³ + 3 = 67, which is not a square mod p
+	offCurve := make([]byte, 32)
+	offCurve[31] = 4
+
+	nonCanonical := make([]byte, 32)
+	putBig(nonCanonical, 0, new(big.Int).Add(P, big.NewInt(1)))
+
+	tests := []struct {
+		name string
+		buf  []byte
+		want error
+	}{
+		{"short", g1[:31], ErrInvalidEncoding},
+		{"infinity with sign", withByte(inf1, 0, compressedFlags), ErrInvalidEncoding},
+		{"infinity with x", withByte(inf1, 31, 1), ErrInvalidEncoding},
+		{"x not on curve", offCurve, ErrInvalidPoint},
+		{"x = p + 1", nonCanonical, ErrNonCanonical},
+	}
+	for _, tt := range tests {
+		if _, err := UnmarshalCompressedG1(tt.buf); err != tt.want {
+			t.Errorf("UnmarshalCompressedG1 %s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	// x = 2 + u is on the twist but outside G2
+	off := twistPointOffSubgroup(t)
+	tests = []struct {
+		name string
+		buf  []byte
+		want error
+	}{
+		{"short", g2[:63], ErrInvalidEncoding},
+		{"infinity with sign", withByte(inf2, 0, compressedFlags), ErrInvalidEncoding},
+		{"infinity with x", withByte(inf2, 63, 1), ErrInvalidEncoding},
+		{"x.b = p", append(append([]byte(nil), g2[:32]...), P.FillBytes(make([]byte, 32))...), ErrNonCanonical},
+		{"outside subgroup", off.MarshalCompressed(), ErrNotInSubgroup},
+	}
+	for _, tt := range tests {
+		if _, err := UnmarshalCompressedG2(tt.buf); err != tt.want {
+			t.Errorf("UnmarshalCompressedG2 %s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestFpAndFp2SqrtForDecompression(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		x, _ := rand.Int(rand.Reader, P)
+		a := NewFp(x)
+		var sq, r Fp
+		fpSquare(&sq, a)
+		if !fpSqrt(&r, &sq) || !r.Square().Equal(&sq) {
+			t.Errorf("fpSqrt failed on a square")
+		}
+
+		b := randomFp2(t)
+		var sq2, r2 Fp2
+		fp2Square(&sq2, b)
+		if !fp2Sqrt(&r2, &sq2) || !r2.Square().Equal(&sq2) {
+			t.Errorf("fp2Sqrt failed on a square")
+		}
+	}
+
+	// -1 is not a square in Fp since p ≡ 3 mod 4, and ξ = 9 + u is not a
+	// square in Fp2 since it defines the Fp6 extension
+	var r Fp
+	if fpSqrt(&r, NewFp(big.NewInt(-1))) {
+		t.Errorf("-1 should not be a square in Fp")
+	}
+	var r2 Fp2
+	if fp2Sqrt(&r2, NewFp2(big.NewInt(9), big.NewInt(1))) {
+		t.Errorf("ξ should not be a square in Fp2")
+	}
+	// u² = -1 takes the α = -1 branch
+	if !fp2Sqrt(&r2, NewFp2(big.NewInt(-1), big.NewInt(0))) || !r2.Square().Equal(NewFp2(big.NewInt(-1), big.NewInt(0))) {
+		t.Errorf("fp2Sqrt(-1) failed")
+	}
+}
+
+// ============================================================================
+// Multi-Scalar Multiplication Tests
+// ============================================================================
+
+// msmInputs returns n random G1 and G2 points and scalars, salted with the
+// inputs that take special paths: zero, negative and oversized scalars,
+// repeated points and the point at infinity.
+func msmInputs(t *testing.T, n int) ([]*G1, []*G2, []*big.Int) {
+	g1s := make([]*G1, n)
+	g2s := make([]*G2, n)
+	ks := make([]*big.Int, n)
+	for i := range ks {
+		k, err := randomScalar(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g1s[i] = ScalarBaseMult(k)
+		g2s[i] = G2Generator().ScalarMult(k)
+		ks[i], _ = rand.Int(rand.Reader, Order)
+	}
+
+	if n >= 5 {
+		ks[0] = big.NewInt(0)
+		ks[1] = big.NewInt(-12345)
+		ks[2] = new(big.Int).Add(Order, big.NewInt(7))
+		g1s[3], g2s[3] = g1s[4], g2s[4]
+		g1s[4], g2s[4] = &G1{}, &G2{}
+	}
+	return g1s, g2s, ks
+}
+
+func TestMultiScalarMulMatchesNaive(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 17, 64} {
+		g1s, g2s, ks := msmInputs(t, n)
+
+		want1, want2 := &G1{}, &G2{}
+		for i := range ks {
+			k := new(big.Int).Mod(ks[i], Order)
+			want1 = want1.Add(g1s[i].ScalarMult(k))
+			want2 = want2.Add(g2s[i].ScalarMult(k))
+		}
+
+		for _, workers := range []int{1, 4} {
+			got1, err := MultiScalarMulG1Workers(g1s, ks, workers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got1.Equal(want1) {
+				t.Errorf("MultiScalarMulG1 mismatch for n=%d, workers=%d", n, workers)
+			}
+
+			got2, err := MultiScalarMulG2Workers(g2s, ks, workers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got2.Equal(want2) {
+				t.Errorf("MultiScalarMulG2 mismatch for n=%d, workers=%d", n, workers)
+			}
+		}
+	}
+}
+
+func TestMultiScalarMulEdgeCases(t *testing.T) {
+	g := G1Generator()
+
+	r, err := MultiScalarMulG1(nil, nil)
+	if err != nil || !r.IsInfinity() {
+		t.Errorf("Empty MSM should be the point at infinity")
+	}
+
+	// k·g + (r-k)·g = infinity
+	k := big.NewInt(99)
+	r, err = MultiScalarMulG1([]*G1{g, g}, []*big.Int{k, new(big.Int).Sub(Order, k)})
+	if err != nil || !r.IsInfinity() {
+		t.Errorf("Cancelling MSM should be the point at infinity")
+	}
+
+	if _, err := MultiScalarMulG1([]*G1{g}, nil); err != ErrLengthMismatch {
+		t.Errorf("Expected ErrLengthMismatch for G1, got %v", err)
+	}
+	if _, err := MultiScalarMulG2([]*G2{G2Generator()}, nil); err != ErrLengthMismatch {
+		t.Errorf("Expected ErrLengthMismatch for G2, got %v", err)
+	}
+
+	// The window choice should grow with the input size
+	if msmWindow(1) > msmWindow(1000) || msmWindow(1000) > msmWindow(100000) {
+		t.Errorf("Window size should not shrink as the input grows")
+	}
+}
+
 // ============================================================================
 // Random Generation Tests
 // ============================================================================
@@ -545,6 +2113,113 @@ func TestG2InvalidPoint(t *testing.T) {
 	}
 }
 
+// twistPointOffSubgroup returns the point (2 + u, y) on the twist, which
+// has a large cofactor and is not in G2
+func twistPointOffSubgroup(t *testing.T) *G2 {
+	x := NewFp2(big.NewInt(2), big.NewInt(1))
+	y := NewFp2(
+		fromHex("101f7278419308b95099eca02dcee0c5381f4d26d1d62313f057167f064101ce"),
+		fromHex("2b76c179599bb92a963dac85546a005a777f7c13f6a7b75d5918b6b5808f5fde"),
+	)
+	p, err := NewG2Unchecked(x, y)
+	if err != nil {
+		t.Fatalf("NewG2Unchecked rejected a point on the twist: %v", err)
+	}
+	return p
+}
+
+func TestG2SubgroupCheck(t *testing.T) {
+	g := G2Generator()
+	if !g.IsInSubgroup() || !g.ScalarMult(big.NewInt(12345)).IsInSubgroup() {
+		t.Errorf("Multiples of the generator should be in the subgroup")
+	}
+	if !(&G2{}).IsInSubgroup() {
+		t.Errorf("Infinity should be in the subgroup")
+	}
+
+	p := twistPointOffSubgroup(t)
+	if g2ScalarMultBinary(p, Order).IsInfinity() {
+		t.Fatalf("Test point should not be killed by Order")
+	}
+	if p.IsInSubgroup() {
+		t.Errorf("Point outside G2 passed the subgroup check")
+	}
+	if _, err := NewG2(&p.X, &p.Y); err != ErrNotInSubgroup {
+		t.Errorf("NewG2 should return ErrNotInSubgroup, got %v", err)
+	}
+	if _, err := UnmarshalG2(p.Marshal()); err != ErrNotInSubgroup {
+		t.Errorf("UnmarshalG2 should return ErrNotInSubgroup, got %v", err)
+	}
+
+	// Multiplying by the cofactor 2p - Order lands in G2
+	h := new(big.Int).Lsh(P, 1)
+	h.Sub(h, Order)
+	cleared := g2ScalarMultBinary(p, h)
+	if !g2ScalarMultBinary(cleared, Order).IsInfinity() {
+		t.Fatalf("Cofactor multiple should have order r")
+	}
+	if !cleared.IsInSubgroup() {
+		t.Errorf("Cofactor multiple should pass the subgroup check")
+	}
+	if _, err := NewG2(&cleared.X, &cleared.Y); err != nil {
+		t.Errorf("NewG2 rejected a subgroup point: %v", err)
+	}
+}
+
+func TestG1FromX(t *testing.T) {
+	g := G1Generator()
+	p, err := G1FromX(big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The generator (1, 2) has an even y
+	if !p.Equal(g) {
+		t.Errorf("G1FromX(1) should be the generator")
+	}
+
+	for i := 0; i < 20; i++ {
+		k, _ := randomScalar(rand.Reader)
+		q := ScalarBaseMult(k)
+		p, err := G1FromX(q.X.BigInt())
+		if err != nil || (!p.Equal(q) && !p.Equal(q.Neg())) {
+			t.Errorf("G1FromX should recover ±q: %v", err)
+		}
+		if fpSgn0(&p.Y) != 0 {
+			t.Errorf("G1FromX should pick the even y")
+		}
+	}
+
+	if _, err := G1FromX(big.NewInt(4)); err != ErrInvalidPoint {
+		t.Errorf("G1FromX(4) should fail with ErrInvalidPoint, got %v", err)
+	}
+}
+
+func TestTwistPointFromX(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		k, _ := randomScalar(rand.Reader)
+		q := G2Generator().ScalarMult(k)
+		p, err := TwistPointFromX(&q.X)
+		if err != nil || (!p.Equal(q) && !p.Equal(q.Neg())) {
+			t.Errorf("TwistPointFromX should recover ±q: %v", err)
+		}
+	}
+
+	// Points outside G2 are returned as is and cleared into it on request
+	off := twistPointOffSubgroup(t)
+	p, err := TwistPointFromX(&off.X)
+	if err != nil || (!p.Equal(off) && !p.Equal(off.Neg())) {
+		t.Fatalf("TwistPointFromX should recover ±off: %v", err)
+	}
+	if p.IsInSubgroup() || !p.ClearCofactor().IsInSubgroup() {
+		t.Errorf("ClearCofactor should map the TwistPointFromX point into G2")
+	}
+
+	// x = 0 gives y² = b', which is not a square
+	if _, err := TwistPointFromX(new(Fp2)); err != ErrInvalidPoint {
+		t.Errorf("TwistPointFromX(0) should fail with ErrInvalidPoint, got %v", err)
+	}
+}
+
 func TestG1LargeScalar(t *testing.T) {
 	g := G1Generator()
 
@@ -0,0 +1,211 @@
+package gobn128
+
+import (
+	"math/big"
+	"sync"
+)
+
+// ============================================================================
+// Fixed-Base Scalar Multiplication - precomputed signed windows
+// ============================================================================
+
+const (
+	// fixedBaseWindow is the window width in bits. Eight-bit windows line
+	// up with the bytes of the scalar.
+	fixedBaseWindow = 8
+	// fixedBaseWindows covers a 256-bit scalar plus the carry out of the
+	// top window produced by the signed recoding
+	fixedBaseWindows = 256/fixedBaseWindow + 1
+	// fixedBaseDigits is the largest digit magnitude after signed recoding
+	fixedBaseDigits = 1 << (fixedBaseWindow - 1)
+)
+
+// fixedBaseDigitsOf recodes k mod Order into signed base-2^8 digits in
+// [-128, 128], least significant first, so that k = Σ d[i]·2^(8i)
+func fixedBaseDigitsOf(k *big.Int) [fixedBaseWindows]int {
+	if k.Sign() < 0 || k.Cmp(Order) >= 0 {
+		k = new(big.Int).Mod(k, Order)
+	}
+	var buf [32]byte
+	k.FillBytes(buf[:])
+
+	var d [fixedBaseWindows]int
+	carry := 0
+	for i := 0; i < 32; i++ {
+		v := int(buf[31-i]) + carry
+		carry = 0
+		if v > fixedBaseDigits {
+			v -= 1 << fixedBaseWindow
+			carry = 1
+		}
+		d[i] = v
+	}
+	d[32] = carry
+	return d
+}
+
+// FixedBaseG1 holds precomputed multiples of a G1 point for fast scalar
+// multiplication by that point, such as Pedersen bases or the powers of
+// tau in a KZG setup. A scalar multiplication costs about 33 mixed
+// additions and no doublings; the table takes ~270KB. It is safe for
+// concurrent use.
+type FixedBaseG1 struct {
+	// table[i][j] = (j+1)·2^(8i)·base; nil for the point at infinity
+	table [][fixedBaseDigits]G1
+}
+
+// NewFixedBaseG1 precomputes the table for base, which costs about as much
+// as 50 calls to ScalarMult
+func NewFixedBaseG1(base *G1) *FixedBaseG1 {
+	if base.IsInfinity() {
+		return &FixedBaseG1{}
+	}
+
+	jac := make([]G1Jac, fixedBaseWindows*fixedBaseDigits)
+	var b G1Jac
+	b.FromAffine(base)
+	for i := 0; i < fixedBaseWindows; i++ {
+		row := jac[i*fixedBaseDigits : (i+1)*fixedBaseDigits]
+		row[0] = b
+		for j := 1; j < fixedBaseDigits; j++ {
+			g1JacAdd(&row[j], &row[j-1], &b)
+		}
+		// The next window's base is 2^8 times this one's
+		for j := 0; j < fixedBaseWindow; j++ {
+			g1JacDouble(&b, &b)
+		}
+	}
+
+	ptrs := make([]*G1Jac, len(jac))
+	for i := range jac {
+		ptrs[i] = &jac[i]
+	}
+	affine := BatchNormalizeG1(ptrs)
+	t := &FixedBaseG1{table: make([][fixedBaseDigits]G1, fixedBaseWindows)}
+	for i := range t.table {
+		for j := range t.table[i] {
+			t.table[i][j] = *affine[i*fixedBaseDigits+j]
+		}
+	}
+	return t
+}
+
+// ScalarMult computes k·base, reducing k modulo Order
+func (t *FixedBaseG1) ScalarMult(k *big.Int) *G1 {
+	if t.table == nil {
+		return &G1{}
+	}
+
+	var acc G1Jac
+	acc.FromAffine(&G1{})
+	for i, d := range fixedBaseDigitsOf(k) {
+		switch {
+		case d > 0:
+			g1JacAddMixed(&acc, &acc, &t.table[i][d-1])
+		case d < 0:
+			q := t.table[i][-d-1]
+			fpNeg(&q.Y, &q.Y)
+			g1JacAddMixed(&acc, &acc, &q)
+		}
+	}
+	return acc.ToAffine()
+}
+
+// ScalarMultFr computes k·base
+func (t *FixedBaseG1) ScalarMultFr(k *Fr) *G1 {
+	return t.ScalarMult(k.BigInt())
+}
+
+// FixedBaseG2 holds precomputed multiples of a G2 point, like FixedBaseG1.
+// The table takes ~540KB. It is safe for concurrent use.
+type FixedBaseG2 struct {
+	// table[i][j] = (j+1)·2^(8i)·base; nil for the point at infinity
+	table [][fixedBaseDigits]G2
+}
+
+// NewFixedBaseG2 precomputes the table for base. base must lie in G2:
+// ScalarMult reduces k modulo Order, which is wrong for other points on
+// the twist, such as those from NewG2Unchecked.
+func NewFixedBaseG2(base *G2) *FixedBaseG2 {
+	if base.IsInfinity() {
+		return &FixedBaseG2{}
+	}
+
+	jac := make([]G2Jac, fixedBaseWindows*fixedBaseDigits)
+	var b G2Jac
+	b.FromAffine(base)
+	for i := 0; i < fixedBaseWindows; i++ {
+		row := jac[i*fixedBaseDigits : (i+1)*fixedBaseDigits]
+		row[0] = b
+		for j := 1; j < fixedBaseDigits; j++ {
+			g2JacAdd(&row[j], &row[j-1], &b)
+		}
+		for j := 0; j < fixedBaseWindow; j++ {
+			g2JacDouble(&b, &b)
+		}
+	}
+
+	ptrs := make([]*G2Jac, len(jac))
+	for i := range jac {
+		ptrs[i] = &jac[i]
+	}
+	affine := BatchNormalizeG2(ptrs)
+	t := &FixedBaseG2{table: make([][fixedBaseDigits]G2, fixedBaseWindows)}
+	for i := range t.table {
+		for j := range t.table[i] {
+			t.table[i][j] = *affine[i*fixedBaseDigits+j]
+		}
+	}
+	return t
+}
+
+// ScalarMult computes k·base, reducing k modulo Order, for a base in G2
+func (t *FixedBaseG2) ScalarMult(k *big.Int) *G2 {
+	if t.table == nil {
+		return &G2{}
+	}
+
+	var acc G2Jac
+	acc.FromAffine(&G2{})
+	for i, d := range fixedBaseDigitsOf(k) {
+		switch {
+		case d > 0:
+			g2JacAddMixed(&acc, &acc, &t.table[i][d-1])
+		case d < 0:
+			q := t.table[i][-d-1]
+			fp2Neg(&q.Y, &q.Y)
+			g2JacAddMixed(&acc, &acc, &q)
+		}
+	}
+	return acc.ToAffine()
+}
+
+// ScalarMultFr computes k·base
+func (t *FixedBaseG2) ScalarMultFr(k *Fr) *G2 {
+	return t.ScalarMult(k.BigInt())
+}
+
+// The generator tables are built on first use
+var (
+	g1GeneratorTableOnce sync.Once
+	g1GeneratorTable     *FixedBaseG1
+
+	g2GeneratorTableOnce sync.Once
+	g2GeneratorTable     *FixedBaseG2
+)
+
+// g1BaseTable returns the fixed-base table of the G1 generator
+func g1BaseTable() *FixedBaseG1 {
+	g1GeneratorTableOnce.Do(func() {
+		g1GeneratorTable = NewFixedBaseG1(G1Generator())
+	})
+	return g1GeneratorTable
+}
+
+// g2BaseTable returns the fixed-base table of the G2 generator
+func g2BaseTable() *FixedBaseG2 {
+	g2GeneratorTableOnce.Do(func() {
+		g2GeneratorTable = NewFixedBaseG2(G2Generator())
+	})
+	return g2GeneratorTable
+}
@@ -0,0 +1,110 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// p as little-endian 64-bit limbs
+DATA fpModulus<>+0(SB)/8, $0x3c208c16d87cfd47
+DATA fpModulus<>+8(SB)/8, $0x97816a916871ca8d
+DATA fpModulus<>+16(SB)/8, $0xb85045b68181585d
+DATA fpModulus<>+24(SB)/8, $0x30644e72e131a029
+GLOBL fpModulus<>(SB), (RODATA+NOPTR), $32
+
+// -p⁻¹ mod 2^64
+DATA fpInvNeg<>(SB)/8, $0x87d20782e4866389
+GLOBL fpInvNeg<>(SB), (RODATA+NOPTR), $8
+
+// One CIOS round, with the multiply and reduce halves each split across the
+// two independent carry chains (CF via ADCX, OF via ADOX):
+//
+//	(A, t) := t + x*y[i]
+//	m := t[0] * (-p⁻¹) mod 2^64
+//	t := (t + m*p) >> 64, t[3] += A
+//
+// x[0..3] live in DI, R8, R9, R10 and t[0..3] in R14, R13, CX, BX.
+// SI holds A and R12 is scratch.
+#define MUL_ROUND(yi) \
+	XORQ  AX, AX;                  \
+	MOVQ  yi, DX;                  \
+	MULXQ DI, AX, SI;              \
+	ADOXQ AX, R14;                 \
+	ADCXQ SI, R13;                 \
+	MULXQ R8, AX, SI;              \
+	ADOXQ AX, R13;                 \
+	ADCXQ SI, CX;                  \
+	MULXQ R9, AX, SI;              \
+	ADOXQ AX, CX;                  \
+	ADCXQ SI, BX;                  \
+	MULXQ R10, AX, SI;             \
+	ADOXQ AX, BX;                  \
+	MOVQ  $0, AX;                  \
+	ADCXQ AX, SI;                  \
+	ADOXQ AX, SI;                  \
+	MOVQ  fpInvNeg<>(SB), DX;      \
+	IMULQ R14, DX;                 \
+	XORQ  AX, AX;                  \
+	MULXQ fpModulus<>+0(SB), AX, R12;  \
+	ADCXQ R14, AX;                 \
+	MOVQ  R12, R14;                \
+	ADCXQ R13, R14;                \
+	MULXQ fpModulus<>+8(SB), AX, R13;  \
+	ADOXQ AX, R14;                 \
+	ADCXQ CX, R13;                 \
+	MULXQ fpModulus<>+16(SB), AX, CX;  \
+	ADOXQ AX, R13;                 \
+	ADCXQ BX, CX;                  \
+	MULXQ fpModulus<>+24(SB), AX, BX;  \
+	ADOXQ AX, CX;                  \
+	MOVQ  $0, AX;                  \
+	ADCXQ AX, BX;                  \
+	ADOXQ SI, BX
+
+// func fpMulADX(z, x, y *Fp)
+TEXT ·fpMulADX(SB), NOSPLIT, $0-24
+	MOVQ x+8(FP), SI
+	MOVQ 0(SI), DI
+	MOVQ 8(SI), R8
+	MOVQ 16(SI), R9
+	MOVQ 24(SI), R10
+	MOVQ y+16(FP), R11
+
+	XORQ R14, R14
+	XORQ R13, R13
+	XORQ CX, CX
+	XORQ BX, BX
+
+	MUL_ROUND(0(R11))
+	MUL_ROUND(8(R11))
+	MUL_ROUND(16(R11))
+	MUL_ROUND(24(R11))
+
+	// Subtract p once if t >= p, keeping t on borrow
+	MOVQ    R14, DI
+	SUBQ    fpModulus<>+0(SB), R14
+	MOVQ    R13, R8
+	SBBQ    fpModulus<>+8(SB), R13
+	MOVQ    CX, R9
+	SBBQ    fpModulus<>+16(SB), CX
+	MOVQ    BX, R10
+	SBBQ    fpModulus<>+24(SB), BX
+	CMOVQCS DI, R14
+	CMOVQCS R8, R13
+	CMOVQCS R9, CX
+	CMOVQCS R10, BX
+
+	MOVQ z+0(FP), AX
+	MOVQ R14, 0(AX)
+	MOVQ R13, 8(AX)
+	MOVQ CX, 16(AX)
+	MOVQ BX, 24(AX)
+	RET
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
@@ -0,0 +1,21 @@
+//go:build !amd64 || purego
+
+package gobn128
+
+// supportADX reports whether the assembly Montgomery kernels are in use
+const supportADX = false
+
+// fpMul sets z = x * y using Montgomery multiplication
+func fpMul(z, x, y *Fp) {
+	fpMulGeneric(z, x, y)
+}
+
+// fpSquare sets z = x²
+func fpSquare(z, x *Fp) {
+	fpMulGeneric(z, x, x)
+}
+
+// montMul sets z = x * y in f
+func montMul(z, x, y *[4]uint64, f *montField) {
+	montMulGeneric(z, x, y, f)
+}
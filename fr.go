@@ -0,0 +1,232 @@
+package gobn128
+
+import (
+	"io"
+	"math/big"
+)
+
+// ============================================================================
+// Fr - Scalar Field Element
+// ============================================================================
+
+// Fr represents an element of the scalar field, the integers modulo Order,
+// in Montgomery form as four little-endian 64-bit limbs. It uses the same
+// representation and CIOS multiplication as Fp. The zero value is 0.
+type Fr [4]uint64
+
+// frModulus is Order as little-endian 64-bit limbs
+var frModulus = Fr{0x43e1f593f0000001, 0x2833e84879b97091, 0xb85045b68181585d, 0x30644e72e131a029}
+
+// frOne is 1 in Montgomery form, i.e. R mod r where R = 2^256
+var frOne = Fr{0xac96341c4ffffffb, 0x36fc76959f60cd29, 0x666ea36f7879462e, 0x0e0a77c19a07df2f}
+
+// frR2 is R² mod r, used to convert into Montgomery form
+var frR2 = Fr{0x1bb8e645ae216da7, 0x53fe3ab1e35c59e3, 0x8c49833d53bb8085, 0x0216d0b17f4e44a5}
+
+// frRMinus2 is r-2, the exponent used for inversion by Fermat's little theorem
+var frRMinus2 = [4]uint64{0x43e1f593efffffff, 0x2833e84879b97091, 0xb85045b68181585d, 0x30644e72e131a029}
+
+// frInvNeg is -r⁻¹ mod 2^64
+const frInvNeg = 0xc2e1f593efffffff
+
+// frField is Fr as a montField, for the limb arithmetic shared with Fp
+var frField = montField{frModulus, frOne, frR2, frRMinus2, frInvNeg}
+
+// NewFr creates a new scalar from big.Int, reducing it modulo Order
+func NewFr(n *big.Int) *Fr {
+	z := new(Fr)
+	frSetBig(z, n)
+	return z
+}
+
+// RandomFr returns a uniformly random non-zero scalar read from rand, or
+// from crypto/rand if rand is nil
+func RandomFr(rand io.Reader) (*Fr, error) {
+	k, err := randomScalar(rand)
+	if err != nil {
+		return nil, err
+	}
+	return NewFr(k), nil
+}
+
+// Copy creates a deep copy of the scalar
+func (f *Fr) Copy() *Fr {
+	z := *f
+	return &z
+}
+
+// Add computes f + g in Fr
+func (f *Fr) Add(g *Fr) *Fr {
+	z := new(Fr)
+	frAdd(z, f, g)
+	return z
+}
+
+// Sub computes f - g in Fr
+func (f *Fr) Sub(g *Fr) *Fr {
+	z := new(Fr)
+	frSub(z, f, g)
+	return z
+}
+
+// Mul computes f * g in Fr
+func (f *Fr) Mul(g *Fr) *Fr {
+	z := new(Fr)
+	frMul(z, f, g)
+	return z
+}
+
+// Square computes f² in Fr
+func (f *Fr) Square() *Fr {
+	z := new(Fr)
+	frMul(z, f, f)
+	return z
+}
+
+// Inverse computes f⁻¹ in Fr using Fermat's little theorem. The inverse of
+// 0 is 0.
+func (f *Fr) Inverse() *Fr {
+	z := new(Fr)
+	frInverse(z, f)
+	return z
+}
+
+// BatchInverseFr returns the inverses of xs with a single inversion and
+// three multiplications per element (Montgomery's trick), as needed for
+// Lagrange coefficients and FFT twiddles. Zero elements are skipped and map
+// to zero, as in Inverse.
+func BatchInverseFr(xs []*Fr) []*Fr {
+	vals := make([]Fr, len(xs))
+	for i, x := range xs {
+		vals[i] = *x
+	}
+	frBatchInverse(vals, vals)
+	out := make([]*Fr, len(xs))
+	for i := range vals {
+		out[i] = &vals[i]
+	}
+	return out
+}
+
+// Neg computes -f in Fr
+func (f *Fr) Neg() *Fr {
+	z := new(Fr)
+	frNeg(z, f)
+	return z
+}
+
+// Exp computes f^e in Fr. A negative e raises the inverse of f to -e.
+func (f *Fr) Exp(e *big.Int) *Fr {
+	base := *f
+	if e.Sign() < 0 {
+		frInverse(&base, &base)
+		e = new(big.Int).Neg(e)
+	}
+	limbs := make([]uint64, 0, (e.BitLen()+63)/64)
+	for i := 0; i < e.BitLen(); i += 64 {
+		limbs = append(limbs, new(big.Int).Rsh(e, uint(i)).Uint64())
+	}
+	z := new(Fr)
+	frExp(z, &base, limbs)
+	return z
+}
+
+// IsZero returns true if f == 0
+func (f *Fr) IsZero() bool {
+	return f[0]|f[1]|f[2]|f[3] == 0
+}
+
+// IsOne returns true if f == 1
+func (f *Fr) IsOne() bool {
+	return *f == frOne
+}
+
+// Equal returns true if f == g
+func (f *Fr) Equal(g *Fr) bool {
+	return *f == *g
+}
+
+// BigInt returns the big.Int representation, in [0, Order)
+func (f *Fr) BigInt() *big.Int {
+	return new(big.Int).SetBytes(f.Bytes())
+}
+
+// Bytes returns the 32-byte big-endian canonical encoding of f
+func (f *Fr) Bytes() []byte {
+	buf := make([]byte, 32)
+	frPutBytes(buf, f)
+	return buf
+}
+
+// SetBytes sets f from a 32-byte big-endian encoding and returns f. It
+// returns ErrInvalidEncoding for any other length and ErrNonCanonical if
+// the value is not below Order, so every scalar has exactly one encoding.
+func (f *Fr) SetBytes(buf []byte) (*Fr, error) {
+	if len(buf) != 32 {
+		return nil, ErrInvalidEncoding
+	}
+	var z Fr
+	if !frSetCanonical(&z, buf) {
+		return nil, ErrNonCanonical
+	}
+	*f = z
+	return f, nil
+}
+
+// frSetBig sets z to n mod r in Montgomery form
+func frSetBig(z *Fr, n *big.Int) {
+	v := n
+	if n.Sign() < 0 || n.Cmp(Order) >= 0 {
+		v = new(big.Int).Mod(n, Order)
+	}
+	var buf [32]byte
+	v.FillBytes(buf[:])
+	frSetCanonical(z, buf[:])
+}
+
+// frSetCanonical sets z from a 32-byte big-endian value, reporting false
+// and leaving z unspecified if the value is not below r
+func frSetCanonical(z *Fr, buf []byte) bool {
+	return montSetCanonical((*[4]uint64)(z), buf, &frField)
+}
+
+// frPutBytes writes the 32-byte big-endian canonical encoding of x into buf
+func frPutBytes(buf []byte, x *Fr) {
+	montPutBytes(buf, (*[4]uint64)(x), &frField)
+}
+
+// frAdd sets z = x + y
+func frAdd(z, x, y *Fr) {
+	montAdd((*[4]uint64)(z), (*[4]uint64)(x), (*[4]uint64)(y), &frField)
+}
+
+// frSub sets z = x - y
+func frSub(z, x, y *Fr) {
+	montSub((*[4]uint64)(z), (*[4]uint64)(x), (*[4]uint64)(y), &frField)
+}
+
+// frNeg sets z = -x
+func frNeg(z, x *Fr) {
+	montNeg((*[4]uint64)(z), (*[4]uint64)(x), &frField)
+}
+
+// frMul sets z = x * y
+func frMul(z, x, y *Fr) {
+	montMul((*[4]uint64)(z), (*[4]uint64)(x), (*[4]uint64)(y), &frField)
+}
+
+// frExp sets z = x^e where e is given as little-endian 64-bit limbs
+func frExp(z, x *Fr, e []uint64) {
+	montExp((*[4]uint64)(z), (*[4]uint64)(x), e, &frField)
+}
+
+// frInverse sets z = x⁻¹ (or 0 if x == 0)
+func frInverse(z, x *Fr) {
+	montInverse((*[4]uint64)(z), (*[4]uint64)(x), &frField)
+}
+
+// frBatchInverse sets z[i] = x[i]⁻¹ (or 0 if x[i] == 0) for every i, using
+// one inversion in total. z and x may be the same slice.
+func frBatchInverse(z, x []Fr) {
+	montBatchInverse(z, x, &frField)
+}
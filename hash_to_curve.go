@@ -0,0 +1,282 @@
+package gobn128
+
+import (
+	"crypto/sha256"
+	"math/big"
+)
+
+// ============================================================================
+// Hash to Curve - BN254 SVDW suites of draft-irtf-cfrg-hash-to-curve
+// ============================================================================
+
+// hashToFieldL is the number of bytes expanded per Fp element,
+// ceil((ceil(log2(p)) + k) / 8) with k = 128, so the reduction mod p has
+// negligible bias
+const hashToFieldL = 48
+
+// expandMessageXMD implements expand_message_xmd with SHA-256 (RFC 9380,
+// section 5.3.1), returning n uniform bytes derived from msg and dst. A dst
+// longer than 255 bytes is first hashed as described in section 5.3.3.
+func expandMessageXMD(msg, dst []byte, n int) []byte {
+	const bInBytes, sInBytes = sha256.Size, sha256.BlockSize
+
+	if len(dst) > 255 {
+		h := sha256.New()
+		h.Write([]byte("H2C-OVERSIZE-DST-"))
+		h.Write(dst)
+		dst = h.Sum(nil)
+	}
+	ell := (n + bInBytes - 1) / bInBytes
+	if ell > 255 || n > 65535 {
+		panic("bn128: expand_message_xmd output too long")
+	}
+	dstPrime := append(append([]byte(nil), dst...), byte(len(dst)))
+
+	// b0 = H(Z_pad || msg || I2OSP(n, 2) || I2OSP(0, 1) || DST')
+	h := sha256.New()
+	h.Write(make([]byte, sInBytes))
+	h.Write(msg)
+	h.Write([]byte{byte(n >> 8), byte(n), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	// b1 = H(b0 || I2OSP(1, 1) || DST'), bi = H((b0 ⊕ b(i-1)) || I2OSP(i, 1) || DST')
+	out := make([]byte, 0, ell*bInBytes)
+	bi := make([]byte, bInBytes)
+	for i := 1; i <= ell; i++ {
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+		h.Reset()
+		h.Write(bi)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		out = append(out, bi...)
+	}
+	return out[:n]
+}
+
+// hashToFp implements hash_to_field for Fp (RFC 9380, section 5.2),
+// returning count field elements derived from msg and dst
+func hashToFp(msg, dst []byte, count int) []Fp {
+	buf := expandMessageXMD(msg, dst, count*hashToFieldL)
+	out := make([]Fp, count)
+	t := new(big.Int)
+	for i := range out {
+		t.SetBytes(buf[i*hashToFieldL : (i+1)*hashToFieldL])
+		fpSetBig(&out[i], t)
+	}
+	return out
+}
+
+// Constants of the Shallue–van de Woestijne map (RFC 9380, section 6.6.1)
+// for y² = x³ + 3 with Z = 1, the choice of the BN254G1 suites of
+// draft-irtf-cfrg-hash-to-curve; RFC 9380 itself defines no BN254 suites
+var (
+	svdwZ = NewFp(big.NewInt(1))
+	// svdwC1 is g(Z) = Z³ + 3
+	svdwC1 = NewFp(big.NewInt(4))
+	// svdwC2 is -Z / 2
+	svdwC2 = NewFp(fromHex("183227397098d014dc2822db40c0ac2ecbc0b548b438e5469e10460b6c3e7ea3"))
+	// svdwC3 is sqrt(-g(Z)·3Z²) with sgn0 = 0
+	svdwC3 = NewFp(fromHex("16789af3a83522eb353c98fc6b36d713d5d8d1cc5dffffffa"))
+	// svdwC4 is -4·g(Z) / (3Z²)
+	svdwC4 = NewFp(fromHex("10216f7ba065e00de81ac1e7808072c9dd2b2385cd7b438469602eb24829a9bd"))
+)
+
+// svdwMapG1 maps a field element to a point on the curve with the
+// straight-line SVDW method of RFC 9380, appendix F.1. G1 has cofactor 1,
+// so the result is always in G1.
+func svdwMapG1(u *Fp) *G1 {
+	var tv1, tv2, tv3, tv4 Fp
+	fpSquare(&tv1, u)
+	fpMul(&tv1, &tv1, svdwC1)
+	fpAdd(&tv2, &fpOne, &tv1)
+	fpSub(&tv1, &fpOne, &tv1)
+	fpMul(&tv3, &tv1, &tv2)
+	fpInverse(&tv3, &tv3)
+	fpMul(&tv4, u, &tv1)
+	fpMul(&tv4, &tv4, &tv3)
+	fpMul(&tv4, &tv4, svdwC3)
+
+	// Three candidates for x; at least one of g(x1), g(x2), g(x3) is square
+	p := new(G1)
+	var x1, x2 Fp
+	fpSub(&x1, svdwC2, &tv4)
+	fpAdd(&x2, svdwC2, &tv4)
+	switch {
+	case g1YFromX(&p.Y, &x1):
+		p.X = x1
+	case g1YFromX(&p.Y, &x2):
+		p.X = x2
+	default:
+		// x3 = Z + c4·(tv2²·tv3)²
+		fpSquare(&p.X, &tv2)
+		fpMul(&p.X, &p.X, &tv3)
+		fpSquare(&p.X, &p.X)
+		fpMul(&p.X, &p.X, svdwC4)
+		fpAdd(&p.X, &p.X, svdwZ)
+		g1YFromX(&p.Y, &p.X)
+	}
+
+	if fpSgn0(u) != fpSgn0(&p.Y) {
+		fpNeg(&p.Y, &p.Y)
+	}
+	return p
+}
+
+// EncodeToG1 implements encode_to_curve for the BN254G1_XMD:SHA-256_SVDW_NU_
+// suite. It is cheaper than HashToG1, but its output is not uniformly
+// distributed, so it must not be used where a random oracle is required.
+// dst is the domain separation tag of the calling protocol.
+func EncodeToG1(msg, dst []byte) *G1 {
+	u := hashToFp(msg, dst, 1)
+	return svdwMapG1(&u[0])
+}
+
+// HashToG1 implements hash_to_curve for the BN254G1_XMD:SHA-256_SVDW_RO_
+// suite of draft-irtf-cfrg-hash-to-curve: two field elements derived with
+// expand_message_xmd are mapped with SVDW and added. The discrete logarithm
+// of the result is unknown, so it is suitable for BLS signatures. dst is the
+// domain separation tag of the calling protocol.
+func HashToG1(msg, dst []byte) *G1 {
+	u := hashToFp(msg, dst, 2)
+	q0, q1 := svdwMapG1(&u[0]), svdwMapG1(&u[1])
+
+	var r G1Jac
+	r.FromAffine(q0)
+	g1JacAddMixed(&r, &r, q1)
+	return r.ToAffine()
+}
+
+// hashToFp2 implements hash_to_field for Fp2 (RFC 9380, section 5.2) with
+// extension degree 2, returning count elements whose coordinates are taken
+// from consecutive hashToFieldL-byte blocks, real part first
+func hashToFp2(msg, dst []byte, count int) []Fp2 {
+	e := hashToFp(msg, dst, 2*count)
+	out := make([]Fp2, count)
+	for i := range out {
+		out[i].a, out[i].b = e[2*i], e[2*i+1]
+	}
+	return out
+}
+
+// Constants of the Shallue–van de Woestijne map for the twist
+// y² = x³ + 3/(9+u) with Z = 1
+var (
+	// svdwG2C1 is g(Z) = Z³ + b'
+	svdwG2C1 = NewFp2(
+		fromHex("2b149d40ceb8aaae81be18991be06ac3b5b4c5e559dbefa33267e6dc24a138e6"),
+		fromHex("9713b03af0fed4cd2cafadeed8fdf4a74fa084e52d1852e4a2bd0685c315d2"),
+	)
+	// svdwG2C3 is sqrt(-g(Z)·3Z²) with sgn0 = 0
+	svdwG2C3 = NewFp2(
+		fromHex("29fd332ab7260112b801fa95b21af64e2e6da55f90a3e510fcbe57377b5ca1ec"),
+		fromHex("303d1eff1426764bf8408aee24ba0b865e76f77b1267a846b1e9154d01565034"),
+	)
+	// svdwG2C4 is -4·g(Z) / (3Z²)
+	svdwG2C4 = NewFp2(
+		fromHex("17365bbe63b1d2078632fe0eb2ac5a41b4e6a9c08b98676721010b008d4eaf99"),
+		fromHex("f57ffe5fc79e19cd689d7aa4209cad8fe164d7f4694786b388732a995d03755"),
+	)
+)
+
+// svdwMapG2 maps an Fp2 element to a point on the twist with the SVDW
+// method of RFC 9380, appendix F.1. Z and c2 = -Z/2 lie in Fp and are
+// shared with svdwMapG1. The result is generally not in G2 and must be
+// passed through ClearCofactor.
+func svdwMapG2(u *Fp2) *G2 {
+	var tv1, tv2, tv3, tv4, one Fp2
+	one.a = fpOne
+	fp2Square(&tv1, u)
+	fp2Mul(&tv1, &tv1, svdwG2C1)
+	fp2Add(&tv2, &one, &tv1)
+	fp2Sub(&tv1, &one, &tv1)
+	fp2Mul(&tv3, &tv1, &tv2)
+	fp2Inverse(&tv3, &tv3)
+	fp2Mul(&tv4, u, &tv1)
+	fp2Mul(&tv4, &tv4, &tv3)
+	fp2Mul(&tv4, &tv4, svdwG2C3)
+
+	p := new(G2)
+	var x1, x2 Fp2
+	fpSub(&x1.a, svdwC2, &tv4.a)
+	fpNeg(&x1.b, &tv4.b)
+	fpAdd(&x2.a, svdwC2, &tv4.a)
+	x2.b = tv4.b
+	switch {
+	case g2YFromX(&p.Y, &x1):
+		p.X = x1
+	case g2YFromX(&p.Y, &x2):
+		p.X = x2
+	default:
+		// x3 = Z + c4·(tv2²·tv3)²
+		fp2Square(&p.X, &tv2)
+		fp2Mul(&p.X, &p.X, &tv3)
+		fp2Square(&p.X, &p.X)
+		fp2Mul(&p.X, &p.X, svdwG2C4)
+		fpAdd(&p.X.a, &p.X.a, svdwZ)
+		g2YFromX(&p.Y, &p.X)
+	}
+
+	if fp2Sgn0(u) != fp2Sgn0(&p.Y) {
+		fp2Neg(&p.Y, &p.Y)
+	}
+	return p
+}
+
+// ClearCofactor maps any point on the twist into G2 by multiplying it by a
+// multiple of the cofactor 2p - Order. It uses the ψ-based decomposition of
+// Fuentes-Castañeda, Knapp and Rodríguez-Henríquez,
+//
+//	[u]Q + ψ([3u]Q) + ψ²([u]Q) + ψ³(Q),
+//
+// which costs one 63-bit scalar multiplication instead of a 254-bit one.
+// This is the h_eff clearing used by the BN254G2 suites of
+// draft-irtf-cfrg-hash-to-curve.
+func (p *G2) ClearCofactor() *G2 {
+	if p.IsInfinity() {
+		return &G2{}
+	}
+
+	var q, xq, x3q, t, r G2Jac
+	q.FromAffine(p)
+	xq.FromAffine(g2ScalarMultBinary(p, curveU))
+	g2JacDouble(&x3q, &xq)
+	g2JacAdd(&x3q, &x3q, &xq)
+
+	// r = [u]Q + ψ([3u]Q) + ψ²([u]Q) + ψ³(Q)
+	g2JacPsi(&t, &x3q)
+	g2JacAdd(&r, &xq, &t)
+	g2JacPsi(&t, &xq)
+	g2JacPsi(&t, &t)
+	g2JacAdd(&r, &r, &t)
+	g2JacPsi(&t, &q)
+	g2JacPsi(&t, &t)
+	g2JacPsi(&t, &t)
+	g2JacAdd(&r, &r, &t)
+	return r.ToAffine()
+}
+
+// EncodeToG2 implements encode_to_curve for the BN254G2_XMD:SHA-256_SVDW_NU_
+// suite. Like EncodeToG1, its output is not uniformly distributed and it
+// must not be used where a random oracle is required.
+func EncodeToG2(msg, dst []byte) *G2 {
+	u := hashToFp2(msg, dst, 1)
+	return svdwMapG2(&u[0]).ClearCofactor()
+}
+
+// HashToG2 implements hash_to_curve for the BN254G2_XMD:SHA-256_SVDW_RO_
+// suite of draft-irtf-cfrg-hash-to-curve: two Fp2 elements are mapped to
+// the twist with SVDW, added, and the sum is moved into G2 with
+// ClearCofactor. dst is the domain separation tag of the calling protocol.
+func HashToG2(msg, dst []byte) *G2 {
+	u := hashToFp2(msg, dst, 2)
+	q0, q1 := svdwMapG2(&u[0]), svdwMapG2(&u[1])
+
+	var r G2Jac
+	r.FromAffine(q0)
+	g2JacAddMixed(&r, &r, q1)
+	return r.ToAffine().ClearCofactor()
+}
@@ -0,0 +1,203 @@
+package gobn128
+
+import (
+	"encoding/binary"
+	"math/big"
+	"runtime"
+	"sync"
+)
+
+// ============================================================================
+// Multi-Scalar Multiplication - Pippenger's bucket method
+// ============================================================================
+
+// msmScalarBits is the bit length of scalars after reduction modulo Order
+const msmScalarBits = 254
+
+// msmWindow picks the window width c that minimises the estimated number of
+// group additions for n points: one addition per point per window, plus
+// about 2·2^c to sum the buckets of each window.
+func msmWindow(n int) uint {
+	best, bestCost := uint(1), -1
+	for c := uint(1); c <= 16; c++ {
+		windows := (msmScalarBits + int(c) - 1) / int(c)
+		cost := windows * (n + 2<<c)
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+	return best
+}
+
+// msmScalars reduces the scalars modulo Order into little-endian limbs
+func msmScalars(scalars []*big.Int) [][4]uint64 {
+	out := make([][4]uint64, len(scalars))
+	var buf [32]byte
+	t := new(big.Int)
+	for i, k := range scalars {
+		if k.Sign() < 0 || k.Cmp(Order) >= 0 {
+			k = t.Mod(k, Order)
+		}
+		k.FillBytes(buf[:])
+		out[i] = [4]uint64{
+			binary.BigEndian.Uint64(buf[24:32]),
+			binary.BigEndian.Uint64(buf[16:24]),
+			binary.BigEndian.Uint64(buf[8:16]),
+			binary.BigEndian.Uint64(buf[0:8]),
+		}
+	}
+	return out
+}
+
+// msmDigit returns the c-bit digit of k starting at bit position pos
+func msmDigit(k *[4]uint64, pos, c uint) uint64 {
+	limb, shift := pos/64, pos%64
+	d := k[limb] >> shift
+	if shift+c > 64 && limb < 3 {
+		d |= k[limb+1] << (64 - shift)
+	}
+	return d & (1<<c - 1)
+}
+
+// msmRun evaluates window(w) for every window w in [0, windows) on up to
+// workers goroutines.
+func msmRun(windows, workers int, window func(w int)) {
+	if workers > windows {
+		workers = windows
+	}
+	if workers <= 1 {
+		for w := 0; w < windows; w++ {
+			window(w)
+		}
+		return
+	}
+
+	next := make(chan int, windows)
+	for w := 0; w < windows; w++ {
+		next <- w
+	}
+	close(next)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for w := range next {
+				window(w)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// MultiScalarMulG1 computes Σ scalars[i]·points[i] using all available CPUs.
+// Scalars are taken modulo Order.
+func MultiScalarMulG1(points []*G1, scalars []*big.Int) (*G1, error) {
+	return MultiScalarMulG1Workers(points, scalars, runtime.GOMAXPROCS(0))
+}
+
+// MultiScalarMulG1Workers computes Σ scalars[i]·points[i] like
+// MultiScalarMulG1, spreading the windows over at most workers goroutines.
+// A worker count of 1 or less runs on the calling goroutine.
+func MultiScalarMulG1Workers(points []*G1, scalars []*big.Int, workers int) (*G1, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrLengthMismatch
+	}
+	if len(points) == 0 {
+		return &G1{}, nil
+	}
+
+	ks := msmScalars(scalars)
+	c := msmWindow(len(points))
+	windows := (msmScalarBits + int(c) - 1) / int(c)
+	sums := make([]G1Jac, windows)
+
+	msmRun(windows, workers, func(w int) {
+		buckets := make([]G1Jac, 1<<c)
+		for i := range buckets {
+			buckets[i].FromAffine(&G1{})
+		}
+		for i, p := range points {
+			if d := msmDigit(&ks[i], uint(w)*c, c); d != 0 {
+				g1JacAddMixed(&buckets[d], &buckets[d], p)
+			}
+		}
+
+		// Σ d·bucket[d] as a sum of running sums from the top bucket down
+		var running, total G1Jac
+		running.FromAffine(&G1{})
+		total.FromAffine(&G1{})
+		for d := len(buckets) - 1; d > 0; d-- {
+			g1JacAdd(&running, &running, &buckets[d])
+			g1JacAdd(&total, &total, &running)
+		}
+		sums[w] = total
+	})
+
+	r := sums[windows-1]
+	for w := windows - 2; w >= 0; w-- {
+		for i := uint(0); i < c; i++ {
+			g1JacDouble(&r, &r)
+		}
+		g1JacAdd(&r, &r, &sums[w])
+	}
+
+	return r.ToAffine(), nil
+}
+
+// MultiScalarMulG2 computes Σ scalars[i]·points[i] using all available CPUs.
+// Scalars are taken modulo Order, so the points must lie in G2: for other
+// points on the twist, such as those from NewG2Unchecked, the sum is wrong.
+func MultiScalarMulG2(points []*G2, scalars []*big.Int) (*G2, error) {
+	return MultiScalarMulG2Workers(points, scalars, runtime.GOMAXPROCS(0))
+}
+
+// MultiScalarMulG2Workers computes Σ scalars[i]·points[i] like
+// MultiScalarMulG2, spreading the windows over at most workers goroutines.
+// A worker count of 1 or less runs on the calling goroutine.
+func MultiScalarMulG2Workers(points []*G2, scalars []*big.Int, workers int) (*G2, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrLengthMismatch
+	}
+	if len(points) == 0 {
+		return &G2{}, nil
+	}
+
+	ks := msmScalars(scalars)
+	c := msmWindow(len(points))
+	windows := (msmScalarBits + int(c) - 1) / int(c)
+	sums := make([]G2Jac, windows)
+
+	msmRun(windows, workers, func(w int) {
+		buckets := make([]G2Jac, 1<<c)
+		for i := range buckets {
+			buckets[i].FromAffine(&G2{})
+		}
+		for i, p := range points {
+			if d := msmDigit(&ks[i], uint(w)*c, c); d != 0 {
+				g2JacAddMixed(&buckets[d], &buckets[d], p)
+			}
+		}
+
+		// Σ d·bucket[d] as a sum of running sums from the top bucket down
+		var running, total G2Jac
+		running.FromAffine(&G2{})
+		total.FromAffine(&G2{})
+		for d := len(buckets) - 1; d > 0; d-- {
+			g2JacAdd(&running, &running, &buckets[d])
+			g2JacAdd(&total, &total, &running)
+		}
+		sums[w] = total
+	})
+
+	r := sums[windows-1]
+	for w := windows - 2; w >= 0; w-- {
+		for i := uint(0); i < c; i++ {
+			g2JacDouble(&r, &r)
+		}
+		g2JacAdd(&r, &r, &sums[w])
+	}
+
+	return r.ToAffine(), nil
+}
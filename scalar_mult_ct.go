@@ -0,0 +1,274 @@
+package gobn128
+
+// ============================================================================
+// Constant-Time Scalar Multiplication
+// ============================================================================
+
+// ScalarMult and ScalarBaseMult run in time that depends on the scalar: they
+// skip leading zeros, branch on digits and use formulas with special cases.
+// ScalarMultCT is for secret scalars such as BLS signing keys and VRF keys.
+// It processes all 256 bits of the scalar in fixed 4-bit windows, reads the
+// window table with a masked scan instead of indexing it, and uses the
+// complete projective formulas of Renes, Costello and Batina ("Complete
+// addition formulas for prime order elliptic curves", algorithms 7 and 9
+// with a = 0), which have no exceptional cases to branch on. Both curves
+// have odd order, as the formulas require. The underlying field operations
+// select with masks rather than branching on their operands.
+
+// ctWindow is the window width of the constant-time ladder
+const ctWindow = 4
+
+// ctEq returns 1 if a == b and 0 otherwise, without branching
+func ctEq(a, b uint64) uint64 {
+	x := a ^ b
+	return 1 ^ ((x | -x) >> 63)
+}
+
+// fpCMov sets z = x if c == 1 and leaves z unchanged if c == 0
+func fpCMov(z, x *Fp, c uint64) {
+	mask := -c
+	z[0] ^= (z[0] ^ x[0]) & mask
+	z[1] ^= (z[1] ^ x[1]) & mask
+	z[2] ^= (z[2] ^ x[2]) & mask
+	z[3] ^= (z[3] ^ x[3]) & mask
+}
+
+// fp2CMov sets z = x if c == 1 and leaves z unchanged if c == 0
+func fp2CMov(z, x *Fp2, c uint64) {
+	fpCMov(&z.a, &x.a, c)
+	fpCMov(&z.b, &x.b, c)
+}
+
+// ctWindowDigit returns window i of the canonical little-endian limbs s
+func ctWindowDigit(s *Fr, i int) uint64 {
+	bit := i * ctWindow
+	return (s[bit/64] >> uint(bit%64)) & (1<<ctWindow - 1)
+}
+
+// g1Proj is a point on E(Fp) in homogeneous projective coordinates,
+// (X, Y, Z) standing for (X/Z, Y/Z), with the point at infinity (0, 1, 0)
+type g1Proj struct {
+	X, Y, Z Fp
+}
+
+// g1B3 is 3b = 9, the constant of the complete formulas on G1
+var g1B3 = curveB.Add(curveB).Add(curveB)
+
+// g2B3 is 3b' = 3·TwistB, the constant of the complete formulas on G2
+var g2B3 = TwistB.Add(TwistB).Add(TwistB)
+
+// g1ProjAddComplete sets z = x + y for any x and y, including equal,
+// opposite and infinite points
+func g1ProjAddComplete(z, x, y *g1Proj) {
+	var t0, t1, t2, t3, t4, x3, y3, z3 Fp
+	fpMul(&t0, &x.X, &y.X)
+	fpMul(&t1, &x.Y, &y.Y)
+	fpMul(&t2, &x.Z, &y.Z)
+	fpAdd(&t3, &x.X, &x.Y)
+	fpAdd(&t4, &y.X, &y.Y)
+	fpMul(&t3, &t3, &t4)
+	fpAdd(&t4, &t0, &t1)
+	fpSub(&t3, &t3, &t4)
+	fpAdd(&t4, &x.Y, &x.Z)
+	fpAdd(&x3, &y.Y, &y.Z)
+	fpMul(&t4, &t4, &x3)
+	fpAdd(&x3, &t1, &t2)
+	fpSub(&t4, &t4, &x3)
+	fpAdd(&x3, &x.X, &x.Z)
+	fpAdd(&y3, &y.X, &y.Z)
+	fpMul(&x3, &x3, &y3)
+	fpAdd(&y3, &t0, &t2)
+	fpSub(&y3, &x3, &y3)
+	fpAdd(&x3, &t0, &t0)
+	fpAdd(&t0, &x3, &t0)
+	fpMul(&t2, &t2, g1B3)
+	fpAdd(&z3, &t1, &t2)
+	fpSub(&t1, &t1, &t2)
+	fpMul(&y3, &y3, g1B3)
+	fpMul(&x3, &t4, &y3)
+	fpMul(&t2, &t3, &t1)
+	fpSub(&x3, &t2, &x3)
+	fpMul(&y3, &y3, &t0)
+	fpMul(&t1, &t1, &z3)
+	fpAdd(&y3, &t1, &y3)
+	fpMul(&t0, &t0, &t3)
+	fpMul(&z3, &z3, &t4)
+	fpAdd(&z3, &z3, &t0)
+	z.X, z.Y, z.Z = x3, y3, z3
+}
+
+// g1ProjDoubleComplete sets z = 2x for any x, including infinity
+func g1ProjDoubleComplete(z, x *g1Proj) {
+	var t0, t1, t2, x3, y3, z3 Fp
+	fpSquare(&t0, &x.Y)
+	fpAdd(&z3, &t0, &t0)
+	fpAdd(&z3, &z3, &z3)
+	fpAdd(&z3, &z3, &z3)
+	fpMul(&t1, &x.Y, &x.Z)
+	fpSquare(&t2, &x.Z)
+	fpMul(&t2, &t2, g1B3)
+	fpMul(&x3, &t2, &z3)
+	fpAdd(&y3, &t0, &t2)
+	fpMul(&z3, &t1, &z3)
+	fpAdd(&t1, &t2, &t2)
+	fpAdd(&t2, &t1, &t2)
+	fpSub(&t0, &t0, &t2)
+	fpMul(&y3, &t0, &y3)
+	fpAdd(&y3, &x3, &y3)
+	fpMul(&t1, &x.X, &x.Y)
+	fpMul(&x3, &t0, &t1)
+	fpAdd(&x3, &x3, &x3)
+	z.X, z.Y, z.Z = x3, y3, z3
+}
+
+// g2ProjAddComplete sets z = x + y for any x and y, including equal,
+// opposite and infinite points
+func g2ProjAddComplete(z, x, y *g2Proj) {
+	var t0, t1, t2, t3, t4, x3, y3, z3 Fp2
+	fp2Mul(&t0, &x.X, &y.X)
+	fp2Mul(&t1, &x.Y, &y.Y)
+	fp2Mul(&t2, &x.Z, &y.Z)
+	fp2Add(&t3, &x.X, &x.Y)
+	fp2Add(&t4, &y.X, &y.Y)
+	fp2Mul(&t3, &t3, &t4)
+	fp2Add(&t4, &t0, &t1)
+	fp2Sub(&t3, &t3, &t4)
+	fp2Add(&t4, &x.Y, &x.Z)
+	fp2Add(&x3, &y.Y, &y.Z)
+	fp2Mul(&t4, &t4, &x3)
+	fp2Add(&x3, &t1, &t2)
+	fp2Sub(&t4, &t4, &x3)
+	fp2Add(&x3, &x.X, &x.Z)
+	fp2Add(&y3, &y.X, &y.Z)
+	fp2Mul(&x3, &x3, &y3)
+	fp2Add(&y3, &t0, &t2)
+	fp2Sub(&y3, &x3, &y3)
+	fp2Add(&x3, &t0, &t0)
+	fp2Add(&t0, &x3, &t0)
+	fp2Mul(&t2, &t2, g2B3)
+	fp2Add(&z3, &t1, &t2)
+	fp2Sub(&t1, &t1, &t2)
+	fp2Mul(&y3, &y3, g2B3)
+	fp2Mul(&x3, &t4, &y3)
+	fp2Mul(&t2, &t3, &t1)
+	fp2Sub(&x3, &t2, &x3)
+	fp2Mul(&y3, &y3, &t0)
+	fp2Mul(&t1, &t1, &z3)
+	fp2Add(&y3, &t1, &y3)
+	fp2Mul(&t0, &t0, &t3)
+	fp2Mul(&z3, &z3, &t4)
+	fp2Add(&z3, &z3, &t0)
+	z.X, z.Y, z.Z = x3, y3, z3
+}
+
+// g2ProjDoubleComplete sets z = 2x for any x, including infinity
+func g2ProjDoubleComplete(z, x *g2Proj) {
+	var t0, t1, t2, x3, y3, z3 Fp2
+	fp2Square(&t0, &x.Y)
+	fp2Add(&z3, &t0, &t0)
+	fp2Add(&z3, &z3, &z3)
+	fp2Add(&z3, &z3, &z3)
+	fp2Mul(&t1, &x.Y, &x.Z)
+	fp2Square(&t2, &x.Z)
+	fp2Mul(&t2, &t2, g2B3)
+	fp2Mul(&x3, &t2, &z3)
+	fp2Add(&y3, &t0, &t2)
+	fp2Mul(&z3, &t1, &z3)
+	fp2Add(&t1, &t2, &t2)
+	fp2Add(&t2, &t1, &t2)
+	fp2Sub(&t0, &t0, &t2)
+	fp2Mul(&y3, &t0, &y3)
+	fp2Add(&y3, &x3, &y3)
+	fp2Mul(&t1, &x.X, &x.Y)
+	fp2Mul(&x3, &t0, &t1)
+	fp2Add(&x3, &x3, &x3)
+	z.X, z.Y, z.Z = x3, y3, z3
+}
+
+// ScalarMultCT computes k*p in time independent of the value of k. The
+// scalar is an Fr rather than a *big.Int because big.Int arithmetic is
+// itself variable-time. Only p, which is treated as public, may affect the
+// running time.
+func (p *G1) ScalarMultCT(k *Fr) *G1 {
+	// table[i] = i·p, with table[0] the point at infinity
+	var table [1 << ctWindow]g1Proj
+	table[0].Y = fpOne
+	if p.IsInfinity() {
+		table[1] = table[0]
+	} else {
+		table[1] = g1Proj{X: p.X, Y: p.Y, Z: fpOne}
+	}
+	for i := 2; i < len(table); i++ {
+		g1ProjAddComplete(&table[i], &table[i-1], &table[1])
+	}
+
+	// Canonical limbs of k, taken out of Montgomery form
+	var s Fr
+	frMul(&s, k, &Fr{1})
+
+	var acc, q g1Proj
+	acc.Y = fpOne
+	for i := 256/ctWindow - 1; i >= 0; i-- {
+		for j := 0; j < ctWindow; j++ {
+			g1ProjDoubleComplete(&acc, &acc)
+		}
+		d := ctWindowDigit(&s, i)
+		for j := range table {
+			c := ctEq(uint64(j), d)
+			fpCMov(&q.X, &table[j].X, c)
+			fpCMov(&q.Y, &table[j].Y, c)
+			fpCMov(&q.Z, &table[j].Z, c)
+		}
+		g1ProjAddComplete(&acc, &acc, &q)
+	}
+
+	// The inverse of Z = 0 is 0, which maps infinity to (0, 0) as required
+	var zInv Fp
+	r := new(G1)
+	fpInverse(&zInv, &acc.Z)
+	fpMul(&r.X, &acc.X, &zInv)
+	fpMul(&r.Y, &acc.Y, &zInv)
+	return r
+}
+
+// ScalarMultCT computes k*p in time independent of the value of k, like
+// G1.ScalarMultCT. k is taken modulo Order, so p must lie in G2.
+func (p *G2) ScalarMultCT(k *Fr) *G2 {
+	var table [1 << ctWindow]g2Proj
+	table[0].Y.a = fpOne
+	if p.IsInfinity() {
+		table[1] = table[0]
+	} else {
+		table[1] = g2Proj{X: p.X, Y: p.Y}
+		table[1].Z.a = fpOne
+	}
+	for i := 2; i < len(table); i++ {
+		g2ProjAddComplete(&table[i], &table[i-1], &table[1])
+	}
+
+	var s Fr
+	frMul(&s, k, &Fr{1})
+
+	var acc, q g2Proj
+	acc.Y.a = fpOne
+	for i := 256/ctWindow - 1; i >= 0; i-- {
+		for j := 0; j < ctWindow; j++ {
+			g2ProjDoubleComplete(&acc, &acc)
+		}
+		d := ctWindowDigit(&s, i)
+		for j := range table {
+			c := ctEq(uint64(j), d)
+			fp2CMov(&q.X, &table[j].X, c)
+			fp2CMov(&q.Y, &table[j].Y, c)
+			fp2CMov(&q.Z, &table[j].Z, c)
+		}
+		g2ProjAddComplete(&acc, &acc, &q)
+	}
+
+	var zInv Fp2
+	r := new(G2)
+	fp2Inverse(&zInv, &acc.Z)
+	fp2Mul(&r.X, &acc.X, &zInv)
+	fp2Mul(&r.Y, &acc.Y, &zInv)
+	return r
+}
@@ -0,0 +1,321 @@
+package gobn128
+
+import (
+	"crypto/rand"
+	"math"
+	"math/big"
+	mrand "math/rand"
+	"os"
+	"sort"
+	"testing"
+	"time"
+)
+
+// ctScalars returns scalars covering the extremes of the window recoding
+// together with random ones
+func ctScalars(t *testing.T) []*Fr {
+	scalars := []*Fr{
+		new(Fr),
+		NewFr(big.NewInt(1)),
+		NewFr(big.NewInt(15)),
+		NewFr(big.NewInt(16)),
+		NewFr(big.NewInt(-1)),
+		NewFr(new(big.Int).Lsh(big.NewInt(1), 253)),
+	}
+	for i := 0; i < 10; i++ {
+		k, err := RandomFr(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scalars = append(scalars, k)
+	}
+	return scalars
+}
+
+func TestScalarMultCT(t *testing.T) {
+	g1 := G1Generator().ScalarMult(big.NewInt(31337))
+	g2 := G2Generator().ScalarMult(big.NewInt(31337))
+
+	for _, k := range ctScalars(t) {
+		if !g1.ScalarMultCT(k).Equal(g1.ScalarMultFr(k)) {
+			t.Errorf("G1 ScalarMultCT disagrees with ScalarMult for k = %s", k.BigInt())
+		}
+		if !g2.ScalarMultCT(k).Equal(g2.ScalarMultFr(k)) {
+			t.Errorf("G2 ScalarMultCT disagrees with ScalarMult for k = %s", k.BigInt())
+		}
+	}
+
+	k := NewFr(big.NewInt(7))
+	if !(&G1{}).ScalarMultCT(k).IsInfinity() {
+		t.Errorf("k·infinity should be infinity in G1")
+	}
+	if !(&G2{}).ScalarMultCT(k).IsInfinity() {
+		t.Errorf("k·infinity should be infinity in G2")
+	}
+}
+
+func TestCompleteFormulas(t *testing.T) {
+	toProj1 := func(p *G1) g1Proj {
+		if p.IsInfinity() {
+			return g1Proj{Y: fpOne}
+		}
+		return g1Proj{X: p.X, Y: p.Y, Z: fpOne}
+	}
+	toAffine1 := func(p *g1Proj) *G1 {
+		var zInv Fp
+		r := new(G1)
+		fpInverse(&zInv, &p.Z)
+		fpMul(&r.X, &p.X, &zInv)
+		fpMul(&r.Y, &p.Y, &zInv)
+		return r
+	}
+
+	p := G1Generator().ScalarMult(big.NewInt(5))
+	q := G1Generator().ScalarMult(big.NewInt(11))
+	inf := &G1{}
+
+	// Every exceptional case of the affine and Jacobian formulas
+	cases := []struct {
+		name string
+		x, y *G1
+	}{
+		{"P + Q", p, q},
+		{"P + P", p, p},
+		{"P + -P", p, p.Neg()},
+		{"P + O", p, inf},
+		{"O + P", inf, p},
+		{"O + O", inf, inf},
+	}
+	for _, c := range cases {
+		x, y := toProj1(c.x), toProj1(c.y)
+		var sum, dbl g1Proj
+		g1ProjAddComplete(&sum, &x, &y)
+		if !toAffine1(&sum).Equal(c.x.Add(c.y)) {
+			t.Errorf("G1 complete addition failed for %s", c.name)
+		}
+		g1ProjDoubleComplete(&dbl, &x)
+		if !toAffine1(&dbl).Equal(c.x.Double()) {
+			t.Errorf("G1 complete doubling failed for %s", c.name)
+		}
+	}
+
+	toProj2 := func(p *G2) g2Proj {
+		r := g2Proj{X: p.X, Y: p.Y}
+		if p.IsInfinity() {
+			r.Y.a = fpOne
+		} else {
+			r.Z.a = fpOne
+		}
+		return r
+	}
+	toAffine2 := func(p *g2Proj) *G2 {
+		var zInv Fp2
+		r := new(G2)
+		fp2Inverse(&zInv, &p.Z)
+		fp2Mul(&r.X, &p.X, &zInv)
+		fp2Mul(&r.Y, &p.Y, &zInv)
+		return r
+	}
+
+	p2 := G2Generator().ScalarMult(big.NewInt(5))
+	for _, y := range []*G2{p2, p2.Neg(), {}, G2Generator()} {
+		x, yp := toProj2(p2), toProj2(y)
+		var sum g2Proj
+		g2ProjAddComplete(&sum, &x, &yp)
+		if !toAffine2(&sum).Equal(p2.Add(y)) {
+			t.Errorf("G2 complete addition failed")
+		}
+	}
+	inf2 := toProj2(&G2{})
+	var dbl g2Proj
+	g2ProjDoubleComplete(&dbl, &inf2)
+	if !toAffine2(&dbl).IsInfinity() {
+		t.Errorf("G2 complete doubling of infinity should be infinity")
+	}
+}
+
+func TestConstantTimeFieldOps(t *testing.T) {
+	one := NewFp(big.NewInt(1))
+	pMinus1 := NewFp(new(big.Int).Sub(P, big.NewInt(1)))
+
+	var z Fp
+	fpCMov(&z, one, 0)
+	if !z.IsZero() {
+		t.Errorf("fpCMov with c = 0 should leave z unchanged")
+	}
+	fpCMov(&z, one, 1)
+	if !z.Equal(one) {
+		t.Errorf("fpCMov with c = 1 should copy x")
+	}
+
+	for _, c := range []struct{ a, b, want uint64 }{
+		{0, 0, 1}, {5, 5, 1}, {5, 6, 0}, {0, 1 << 63, 0}, {^uint64(0), ^uint64(0), 1},
+	} {
+		if got := ctEq(c.a, c.b); got != c.want {
+			t.Errorf("ctEq(%d, %d) = %d, expected %d", c.a, c.b, got, c.want)
+		}
+	}
+
+	// The masked reductions at their boundaries
+	if !pMinus1.Add(one).IsZero() {
+		t.Errorf("(p-1) + 1 should be 0")
+	}
+	if !new(Fp).Sub(one).Equal(pMinus1) {
+		t.Errorf("0 - 1 should be p-1")
+	}
+	if !new(Fp).Neg().IsZero() {
+		t.Errorf("-0 should be 0")
+	}
+	if !NewFr(big.NewInt(0)).Sub(NewFr(big.NewInt(1))).Equal(NewFr(big.NewInt(-1))) {
+		t.Errorf("0 - 1 should be r-1 in Fr")
+	}
+}
+
+// ============================================================================
+// Timing Leakage Tests
+// ============================================================================
+
+// The timing tests follow dudect (Reparaz, Balasch and Verbauwhede, "Dude,
+// is my code constant time?"): time an operation on inputs from two classes,
+// a fixed value and random values, interleaved in random order, discard the
+// slowest measurements as noise and compare the classes with Welch's t-test.
+// A |t| far above dudectThreshold means the running time depends on the
+// input class.
+
+// dudectThreshold is the |t| above which dudect reports a definite leak
+const dudectThreshold = 10
+
+// dudectSamples is the number of measurements per class
+const dudectSamples = 1500
+
+// dudectStrict reports whether the timing tests enforce dudectThreshold.
+// Timings on shared or loaded machines are too noisy to gate ordinary test
+// runs, so by default the tests only log t; set BN128_DUDECT=1 to fail on it.
+func dudectStrict() bool {
+	return os.Getenv("BN128_DUDECT") == "1"
+}
+
+// dudectT measures op on n inputs of each class, with fixed supplying the
+// class 0 input and random the class 1 inputs, and returns Welch's t
+// statistic between the two classes
+func dudectT(n int, fixed *Fr, random func() *Fr, op func(*Fr)) float64 {
+	type sample struct {
+		class int
+		k     *Fr
+		ns    float64
+	}
+	samples := make([]sample, 0, 2*n)
+	for i := 0; i < n; i++ {
+		samples = append(samples, sample{class: 0, k: fixed}, sample{class: 1, k: random()})
+	}
+	rng := mrand.New(mrand.NewSource(1))
+	rng.Shuffle(len(samples), func(i, j int) { samples[i], samples[j] = samples[j], samples[i] })
+
+	// Warm up caches and the branch predictor
+	for i := 0; i < 10; i++ {
+		op(samples[i].k)
+	}
+	for i := range samples {
+		start := time.Now()
+		op(samples[i].k)
+		samples[i].ns = float64(time.Since(start).Nanoseconds())
+	}
+
+	// Drop measurements above the 90th percentile, which are dominated by
+	// interrupts, scheduling and garbage collection
+	sorted := make([]float64, len(samples))
+	for i := range samples {
+		sorted[i] = samples[i].ns
+	}
+	sort.Float64s(sorted)
+	cutoff := sorted[len(sorted)*9/10]
+
+	var classes [2][]float64
+	for _, s := range samples {
+		if s.ns <= cutoff {
+			classes[s.class] = append(classes[s.class], s.ns)
+		}
+	}
+	return welchT(classes[0], classes[1])
+}
+
+// welchT returns Welch's t statistic for the difference of the means of a
+// and b
+func welchT(a, b []float64) float64 {
+	meanVar := func(xs []float64) (float64, float64) {
+		var sum float64
+		for _, x := range xs {
+			sum += x
+		}
+		mean := sum / float64(len(xs))
+		var ss float64
+		for _, x := range xs {
+			ss += (x - mean) * (x - mean)
+		}
+		return mean, ss / float64(len(xs)-1)
+	}
+	ma, va := meanVar(a)
+	mb, vb := meanVar(b)
+	den := math.Sqrt(va/float64(len(a)) + vb/float64(len(b)))
+	if den == 0 {
+		return 0
+	}
+	return (ma - mb) / den
+}
+
+// randomFrOrFatal returns a generator of random scalars for dudectT
+func randomFrOrFatal(t *testing.T) func() *Fr {
+	return func() *Fr {
+		k, err := RandomFr(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+}
+
+func TestScalarMultCTTiming(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test skipped in short mode")
+	}
+
+	g1 := G1Generator()
+	g2 := G2Generator()
+
+	// k = 1 is the cheapest input for a variable-time ladder: one set bit,
+	// no leading digits, and table index 1 in every lookup that matters
+	fixed := NewFr(big.NewInt(1))
+
+	tests := []struct {
+		name string
+		op   func(*Fr)
+	}{
+		{"G1", func(k *Fr) { g1.ScalarMultCT(k) }},
+		{"G2", func(k *Fr) { g2.ScalarMultCT(k) }},
+	}
+	for _, tt := range tests {
+		tStat := dudectT(dudectSamples, fixed, randomFrOrFatal(t), tt.op)
+		t.Logf("%s ScalarMultCT: t = %.2f", tt.name, tStat)
+		if dudectStrict() && math.Abs(tStat) > dudectThreshold {
+			t.Errorf("%s ScalarMultCT timing depends on the scalar: |t| = %.2f > %d",
+				tt.name, math.Abs(tStat), dudectThreshold)
+		}
+	}
+}
+
+// TestTimingHarnessDetectsLeak checks that the harness flags the
+// variable-time ScalarMult, so a passing TestScalarMultCTTiming under
+// BN128_DUDECT=1 means something
+func TestTimingHarnessDetectsLeak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test skipped in short mode")
+	}
+
+	g1 := G1Generator()
+	fixed := NewFr(big.NewInt(1))
+	tStat := dudectT(500, fixed, randomFrOrFatal(t), func(k *Fr) { g1.ScalarMultFr(k) })
+	t.Logf("G1 ScalarMult: t = %.2f", tStat)
+	if dudectStrict() && math.Abs(tStat) < dudectThreshold {
+		t.Errorf("Timing harness missed the leak in ScalarMult: |t| = %.2f", math.Abs(tStat))
+	}
+}